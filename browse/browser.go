@@ -0,0 +1,523 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// the ANSI sequences used to draw the screen
+const (
+	bold    = "\x1b[1m"
+	reverse = "\x1b[7m"
+	reset   = "\x1b[0m"
+)
+
+// panelGap is the space between the table & results when they're
+// shown side by side
+const panelGap = "   "
+
+// SideBySideWidth is the narrowest the screen can be and still show the
+// table & results side by side, on narrower screens they're stacked
+const SideBySideWidth = 72
+
+// view is one of the screens the browser can show
+type view int
+
+const (
+	viewDay view = iota
+	viewTeam
+	viewSearch
+)
+
+// Browser holds the state of the season browser. It doesn't know anything
+// about the terminal; keys are passed to HandleKey and the screen is drawn
+// from View, which keeps it easy to test.
+type Browser struct {
+	season games.Season
+	view   view
+
+	// day is the index of the match day being shown, selected is the
+	// selected row in that day's table
+	day      int
+	selected int
+
+	// team is the team shown in the team view, and teamRow is the
+	// selected match in their history
+	team    string
+	teamRow int
+
+	// query is the search text, found are the teams that match it and
+	// foundRow is the selected team
+	query    string
+	found    []string
+	foundRow int
+
+	// history is the screens to go back to when escape is pressed
+	history []screen
+}
+
+// screen is what's needed to get back to an earlier view
+type screen struct {
+	view    view
+	team    string
+	teamRow int
+}
+
+// New creates a browser for the season, starting on the last match day
+func New(s games.Season) *Browser {
+	b := &Browser{season: s}
+	if len(s.Days) > 0 {
+		b.day = len(s.Days) - 1
+	}
+	return b
+}
+
+// HandleKey updates the browser for a key press, it returns false
+// when the browser should be closed
+func (b *Browser) HandleKey(k term.Key) bool {
+	if k.Code == term.KeyCtrlC {
+		return false
+	}
+
+	switch b.view {
+	case viewSearch:
+		b.searchKey(k)
+		return true
+	case viewTeam:
+		return b.teamKey(k)
+	}
+	return b.dayKey(k)
+}
+
+// dayKey handles a key press in the match day view
+func (b *Browser) dayKey(k term.Key) bool {
+	standings := b.standings()
+	switch {
+	case k.Code == term.KeyLeft || k.Rune == 'h':
+		b.showDay(b.day - 1)
+	case k.Code == term.KeyRight || k.Rune == 'l':
+		b.showDay(b.day + 1)
+	case k.Code == term.KeyHome || k.Rune == 'g':
+		b.showDay(0)
+	case k.Code == term.KeyEnd || k.Rune == 'G':
+		b.showDay(len(b.season.Days) - 1)
+	case k.Code == term.KeyUp || k.Rune == 'k':
+		b.selected = clamp(b.selected-1, len(standings))
+	case k.Code == term.KeyDown || k.Rune == 'j':
+		b.selected = clamp(b.selected+1, len(standings))
+	case k.Code == term.KeyEnter:
+		if b.selected < len(standings) {
+			b.showTeam(standings[b.selected].Team)
+		}
+	case k.Rune == '/':
+		b.startSearch()
+	case k.Rune == 'q' || k.Code == term.KeyEscape:
+		return false
+	}
+	return true
+}
+
+// teamKey handles a key press in the team view
+func (b *Browser) teamKey(k term.Key) bool {
+	matches := b.season.TeamMatches(b.team)
+	switch {
+	case k.Code == term.KeyUp || k.Rune == 'k':
+		b.teamRow = clamp(b.teamRow-1, len(matches))
+	case k.Code == term.KeyDown || k.Rune == 'j':
+		b.teamRow = clamp(b.teamRow+1, len(matches))
+	case k.Code == term.KeyEnter:
+		if b.teamRow < len(matches) {
+			b.showTeam(matches[b.teamRow].Opponent)
+		}
+	case k.Rune == '/':
+		b.startSearch()
+	case k.Code == term.KeyEscape || k.Code == term.KeyBackspace:
+		b.back()
+	case k.Rune == 'q':
+		return false
+	}
+	return true
+}
+
+// searchKey handles a key press in the search view
+func (b *Browser) searchKey(k term.Key) {
+	switch k.Code {
+	case term.KeyUp:
+		b.foundRow = clamp(b.foundRow-1, len(b.found))
+	case term.KeyDown, term.KeyTab:
+		b.foundRow = clamp(b.foundRow+1, len(b.found))
+	case term.KeyEnter:
+		if b.foundRow < len(b.found) {
+			b.back()
+			b.showTeam(b.found[b.foundRow])
+		}
+	case term.KeyEscape:
+		b.back()
+	case term.KeyBackspace:
+		if q := []rune(b.query); len(q) > 0 {
+			b.query = string(q[:len(q)-1])
+			b.search()
+		}
+	case term.KeyRune:
+		b.query += string(k.Rune)
+		b.search()
+	}
+}
+
+// startSearch switches to the search view with an empty query
+func (b *Browser) startSearch() {
+	b.push()
+	b.view = viewSearch
+	b.query = ""
+	b.search()
+}
+
+// search finds the teams with names containing the query,
+// ignoring case
+func (b *Browser) search() {
+	b.found = []string{}
+	b.foundRow = 0
+	q := strings.ToLower(b.query)
+	for _, t := range b.season.Teams {
+		if strings.Contains(strings.ToLower(t), q) {
+			b.found = append(b.found, t)
+		}
+	}
+}
+
+// showDay switches to the match day with the given index, keeping
+// the same team selected if they played on that day
+func (b *Browser) showDay(d int) {
+	d = clamp(d, len(b.season.Days))
+	if d == b.day {
+		return
+	}
+
+	team := ""
+	if st := b.standings(); b.selected < len(st) {
+		team = st[b.selected].Team
+	}
+
+	b.day = d
+	st := b.standings()
+	b.selected = clamp(b.selected, len(st))
+	for i, s := range st {
+		if s.Team == team {
+			b.selected = i
+		}
+	}
+}
+
+// showTeam switches to the history of the named team
+func (b *Browser) showTeam(name string) {
+	if b.view == viewTeam && b.team == name {
+		return
+	}
+	b.push()
+	b.view = viewTeam
+	b.team = name
+	b.teamRow = 0
+}
+
+// push saves the current screen so back can return to it
+func (b *Browser) push() {
+	b.history = append(b.history, screen{view: b.view, team: b.team, teamRow: b.teamRow})
+}
+
+// back returns to the previous screen, or the match day
+// view if there isn't one
+func (b *Browser) back() {
+	l := len(b.history)
+	if l == 0 {
+		b.view = viewDay
+		return
+	}
+
+	sc := b.history[l-1]
+	b.history = b.history[:l-1]
+	b.view, b.team, b.teamRow = sc.view, sc.team, sc.teamRow
+}
+
+// standings returns the standings for the current day
+func (b *Browser) standings() []games.Standing {
+	if b.day >= len(b.season.Days) {
+		return nil
+	}
+	return b.season.Days[b.day].Standings
+}
+
+// View draws the screen as lines of text, each no wider than width,
+// with no more than height lines
+func (b *Browser) View(width, height int) []string {
+	var title, help string
+	var body []string
+	var focus int
+
+	switch b.view {
+	case viewTeam:
+		title, body, focus = b.teamView(width)
+		help = "↑/↓ select  enter opponent  / search  esc back  q quit"
+	case viewSearch:
+		title, body, focus = b.searchView(width)
+		help = "type to search  ↑/↓ select  enter open  esc cancel"
+	default:
+		title, body, focus = b.dayView(width)
+		help = "←/→ day  ↑/↓ select  enter team  / search  q quit"
+	}
+
+	lines := []string{bold + fit(title, width) + reset}
+	avail := height - 2
+	if avail < 0 {
+		avail = 0
+	}
+
+	// scroll so the focused line is on the screen
+	if focus >= avail && avail > 0 {
+		body = body[focus-avail+1:]
+	}
+	if len(body) > avail {
+		body = body[:avail]
+	}
+	lines = append(lines, body...)
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	if height > 1 {
+		lines = append(lines, fit(help, width))
+	}
+	return lines
+}
+
+// dayView draws the league table & results for the current match day,
+// along with the index of the line with the selected team
+func (b *Browser) dayView(width int) (string, []string, int) {
+	if len(b.season.Days) == 0 {
+		return "No match days", []string{}, 0
+	}
+
+	d := b.season.Days[b.day]
+	title := fmt.Sprintf("Matchday %v of %v", d.Number, len(b.season.Days))
+
+	rows := [][]string{{"Pos", "Team", "Pts"}}
+	for _, s := range d.Standings {
+		rows = append(rows, []string{fmt.Sprintf("%v", s.Position), s.Team, fmt.Sprintf("%v", s.Points)})
+	}
+	table := columns(rows, []bool{true, false, true})
+
+	results := []string{"Results"}
+	for _, m := range d.Matches {
+		results = append(results, matchLine(m))
+	}
+
+	tw := 0
+	for _, l := range table {
+		if n := utf8.RuneCountInString(l); n > tw {
+			tw = n
+		}
+	}
+
+	highlight := func(i int, l string) string {
+		switch {
+		case i == 0:
+			return bold + l + reset
+		case i-1 == b.selected:
+			return reverse + l + reset
+		}
+		return l
+	}
+
+	body := []string{}
+	if width < SideBySideWidth {
+		for i, l := range table {
+			body = append(body, highlight(i, fit(l, width)))
+		}
+		body = append(body, "")
+		for i, l := range results {
+			if i == 0 {
+				l = bold + fit(l, width) + reset
+			} else {
+				l = fit(l, width)
+			}
+			body = append(body, l)
+		}
+		return title, body, b.selected + 1
+	}
+
+	if max := width / 2; tw > max {
+		tw = max
+	}
+	rw := width - tw - utf8.RuneCountInString(panelGap)
+	for i := 0; i < len(table) || i < len(results); i++ {
+		left := strings.Repeat(" ", tw)
+		if i < len(table) {
+			left = highlight(i, pad(fit(table[i], tw), tw))
+		}
+		right := ""
+		if i < len(results) {
+			right = fit(results[i], rw)
+			if i == 0 {
+				right = bold + right + reset
+			}
+		}
+		body = append(body, strings.TrimRight(left+panelGap+right, " "))
+	}
+	return title, body, b.selected + 1
+}
+
+// teamView draws the named team's results over the season, along
+// with the index of the line with the selected match
+func (b *Browser) teamView(width int) (string, []string, int) {
+	matches := b.season.TeamMatches(b.team)
+
+	title := b.team
+	if l := len(b.season.Days); l > 0 {
+		for _, s := range b.season.Days[l-1].Standings {
+			if s.Team == b.team {
+				title = fmt.Sprintf("%v, %v with %v %v", b.team, ordinal(s.Position), s.Points, pts(s.Points))
+			}
+		}
+	}
+
+	rows := [][]string{{"Day", "Opponent", "Score", "", "Pts", "Pos"}}
+	for _, m := range matches {
+		score := fmt.Sprintf("%v-%v", m.GoalsFor, m.GoalsAgainst)
+		if m.Status == "postponed" {
+			score = "P"
+		}
+		result := m.Result
+		if m.Status != "played" {
+			result = m.Status
+		}
+		rows = append(rows, []string{fmt.Sprintf("%v", m.Day), m.Opponent, score, result, fmt.Sprintf("%v", m.Points), fmt.Sprintf("%v", m.Position)})
+	}
+
+	body := []string{}
+	for i, l := range columns(rows, []bool{true, false, true, false, true, true}) {
+		l = fit(l, width)
+		switch {
+		case i == 0:
+			l = bold + l + reset
+		case i-1 == b.teamRow:
+			l = reverse + l + reset
+		}
+		body = append(body, l)
+	}
+	return title, body, b.teamRow + 1
+}
+
+// searchView draws the search box & the teams that match, along
+// with the index of the line with the selected team
+func (b *Browser) searchView(width int) (string, []string, int) {
+	body := []string{}
+	for i, t := range b.found {
+		t = fit(t, width)
+		if i == b.foundRow {
+			t = reverse + t + reset
+		}
+		body = append(body, t)
+	}
+	if len(b.found) == 0 {
+		body = append(body, "No teams found")
+	}
+	return fmt.Sprintf("Search: %v", b.query), body, b.foundRow
+}
+
+// matchLine describes a match for the results panel
+func matchLine(m games.Match) string {
+	l := fmt.Sprintf("%v %v - %v %v", m.Team1, m.Score1, m.Score2, m.Team2)
+	switch {
+	case m.Status == "postponed":
+		l = fmt.Sprintf("%v v %v (postponed)", m.Team1, m.Team2)
+	case m.Status != "played":
+		l = fmt.Sprintf("%v (%v)", l, m.Status)
+	case m.Forfeit != "":
+		l = fmt.Sprintf("%v (%v forfeit)", l, m.Forfeit)
+	case m.RescheduledFrom != 0:
+		l = fmt.Sprintf("%v (from day %v)", l, m.RescheduledFrom)
+	}
+	return l
+}
+
+// columns lines up the rows into columns, right aligning the
+// columns where right is true
+func columns(rows [][]string, right []bool) []string {
+	widths := make([]int, len(right))
+	for _, r := range rows {
+		for i, c := range r {
+			if n := utf8.RuneCountInString(c); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	out := []string{}
+	for _, r := range rows {
+		cells := make([]string, len(r))
+		for i, c := range r {
+			if right[i] {
+				cells[i] = strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)) + c
+			} else {
+				cells[i] = pad(c, widths[i])
+			}
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	return out
+}
+
+// fit cuts the string down to the width
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+// pad adds spaces to the end of the string to make it the width
+func pad(s string, width int) string {
+	if n := width - utf8.RuneCountInString(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// clamp keeps the index in the range [0, n)
+func clamp(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// pts is "pt" or "pts" for the number of points
+func pts(n int) string {
+	if n == 1 || n == -1 {
+		return "pt"
+	}
+	return "pts"
+}
+
+// ordinal returns the number with an English ordinal suffix, like "1st"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%v%v", n, suffix)
+}
@@ -0,0 +1,158 @@
+package browse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+var ansi = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// testSeason builds a small season to browse
+func testSeason(t *testing.T) games.Season {
+	r := games.NewRanking()
+	for _, l := range []string{"Aptos 1, Boulder Creek 0", "Capitola 0, Davenport 0", "Boulder Creek 2, Capitola 0", "Aptos 0, Davenport 1"} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	return r.Model()
+}
+
+// keys turns a string into key presses, with some names
+// in braces for the special keys
+func keys(in string) []term.Key {
+	named := map[string]term.KeyCode{"{left}": term.KeyLeft, "{right}": term.KeyRight, "{up}": term.KeyUp, "{down}": term.KeyDown, "{enter}": term.KeyEnter, "{esc}": term.KeyEscape, "{bs}": term.KeyBackspace}
+	out := []term.Key{}
+	for len(in) > 0 {
+		found := false
+		for n, c := range named {
+			if strings.HasPrefix(in, n) {
+				out = append(out, term.Key{Code: c})
+				in = in[len(n):]
+				found = true
+			}
+		}
+		if !found {
+			r := []rune(in)[0]
+			out = append(out, term.Key{Code: term.KeyRune, Rune: r})
+			in = in[len(string(r)):]
+		}
+	}
+	return out
+}
+
+func TestBrowse_Browser_View(t *testing.T) {
+	tests := []struct {
+		keys   string
+		width  int
+		height int
+		expect string
+	}{
+		{"", 80, 8, `Matchday 2 of 2
+Pos  Team           Pts   Results
+  1  Davenport        4   Boulder Creek 2 - 0 Capitola
+  2  Aptos            3   Aptos 0 - 1 Davenport
+  3  Boulder Creek    3
+  4  Capitola         1
+
+←/→ day  ↑/↓ select  enter team  / search  q quit`},
+		{"{left}", 40, 12, `Matchday 1 of 2
+Pos  Team           Pts
+  1  Aptos            3
+  2  Capitola         1
+  3  Davenport        1
+  4  Boulder Creek    0
+
+Results
+Aptos 1 - 0 Boulder Creek
+Capitola 0 - 0 Davenport
+
+←/→ day  ↑/↓ select  enter team  / sear…`},
+		{"{down}{down}{enter}", 80, 5, `Boulder Creek, 3rd with 3 pts
+Day  Opponent  Score     Pts  Pos
+  1  Aptos       0-1  L    0    4
+  2  Capitola    2-0  W    3    3
+↑/↓ select  enter opponent  / search  esc back  q quit`},
+		{"{down}{down}{enter}{down}{enter}", 80, 5, `Capitola, 4th with 1 pt
+Day  Opponent       Score     Pts  Pos
+  1  Davenport        0-0  D    1    2
+  2  Boulder Creek    0-2  L    1    4
+↑/↓ select  enter opponent  / search  esc back  q quit`},
+		{"{down}{down}{enter}{down}{enter}{esc}", 80, 3, `Boulder Creek, 3rd with 3 pts
+  2  Capitola    2-0  W    3    3
+↑/↓ select  enter opponent  / search  esc back  q quit`},
+		{"/AP", 80, 4, `Search: AP
+Aptos
+Capitola
+type to search  ↑/↓ select  enter open  esc cancel`},
+		{"/ap{down}{enter}", 80, 3, `Capitola, 4th with 1 pt
+  1  Davenport        0-0  D    1    2
+↑/↓ select  enter opponent  / search  esc back  q quit`},
+		{"/zz{bs}{bs}{bs}", 80, 3, `Search: 
+Aptos
+type to search  ↑/↓ select  enter open  esc cancel`},
+		{"/ap{enter}{esc}{down}{down}{down}", 80, 4, `Matchday 2 of 2
+  3  Boulder Creek    3
+  4  Capitola         1
+←/→ day  ↑/↓ select  enter team  / search  q quit`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			b := New(testSeason(t))
+			for _, k := range keys(tt.keys) {
+				if !b.HandleKey(k) {
+					t.Fatalf("browser closed on key %+v", k)
+				}
+			}
+
+			got := ansi.ReplaceAllString(strings.Join(b.View(tt.width, tt.height), "\n"), "")
+			if got != tt.expect {
+				t.Errorf("wrong view\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestBrowse_Browser_Selection(t *testing.T) {
+	b := New(testSeason(t))
+	for _, k := range keys("{down}{left}") {
+		b.HandleKey(k)
+	}
+
+	// Aptos was selected on day 2, and is still selected on day 1
+	lines := b.View(80, 10)
+	if !strings.Contains(lines[2], reverse) || !strings.Contains(lines[2], "Aptos") {
+		t.Errorf("expected Aptos to be selected, got %q", lines[2])
+	}
+}
+
+func TestBrowse_Browser_Quit(t *testing.T) {
+	tests := []string{"q", "{esc}"}
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			b := New(testSeason(t))
+			ks := keys(tt)
+			if b.HandleKey(ks[0]) {
+				t.Errorf("expected browser to close")
+			}
+		})
+	}
+
+	b := New(testSeason(t))
+	b.HandleKey(term.Key{Code: term.KeyRune, Rune: '/'})
+	if !b.HandleKey(term.Key{Code: term.KeyRune, Rune: 'q'}) {
+		t.Errorf("expected 'q' to be typed into the search, not close the browser")
+	}
+	if b.HandleKey(term.Key{Code: term.KeyCtrlC}) {
+		t.Errorf("expected ctrl-c to close the browser")
+	}
+}
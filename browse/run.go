@@ -0,0 +1,90 @@
+package browse
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// the ANSI sequences used to take over the terminal
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	cursorHide   = "\x1b[?25l"
+	cursorShow   = "\x1b[?25h"
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+)
+
+// Run opens the browser full screen on the terminal, reading keys from in
+// and drawing to out, until the user quits. The terminal is put back the
+// way it was before returning.
+func Run(in, out *os.File, s games.Season) error {
+	restore, err := term.MakeRaw(in)
+	if err != nil {
+		return fmt.Errorf("unable to set up the terminal: %w", err)
+	}
+	defer restore()
+
+	w := bufio.NewWriter(out)
+	fmt.Fprint(w, altScreenOn, cursorHide)
+	defer func() {
+		fmt.Fprint(w, cursorShow, altScreenOff)
+		w.Flush()
+	}()
+
+	keys := make(chan []term.Key)
+	errs := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				errs <- err
+				return
+			}
+			keys <- term.ParseKeys(buf[:n])
+		}
+	}()
+
+	resized := make(chan os.Signal, 1)
+	stop := term.NotifyResize(resized)
+	defer stop()
+
+	b := New(s)
+	for {
+		width, height, err := term.Size(out)
+		if err != nil {
+			return fmt.Errorf("unable to get the terminal size: %w", err)
+		}
+		if err := draw(w, b.View(width, height)); err != nil {
+			return err
+		}
+
+		select {
+		case ks := <-keys:
+			for _, k := range ks {
+				if !b.HandleKey(k) {
+					return nil
+				}
+			}
+		case <-resized:
+		case err := <-errs:
+			return fmt.Errorf("unable to read from the terminal: %w", err)
+		}
+	}
+}
+
+// draw writes the lines over the top of the last screen
+func draw(w *bufio.Writer, lines []string) error {
+	out := cursorHome + strings.Join(lines, clearLine+"\r\n") + clearLine + clearBelow
+	if _, err := w.WriteString(out); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/browse"
+	"github.com/seanhagen/jane-coding-challenge/term"
+	"github.com/spf13/cobra"
+)
+
+// browseCmd represents the browse command
+var browseCmd = &cobra.Command{
+	Use:   "browse path/to/match-data.txt",
+	Short: "Browse a season of match data in the terminal",
+	Long: `Reads match data the same way as the parse command, then opens a full screen
+view of the season in the terminal.
+
+The match day view shows the league table next to that day's results:
+  ←/→ or h/l     previous & next match day
+  home/end       first & last match day
+  ↑/↓ or k/j     select a team in the table
+  enter          show the selected team's history
+  /              search for a team by name
+  q              quit
+
+The team view shows every match the team played, with their points and
+position after each one. Press enter on a match to show the opponent's
+history, and escape to go back.`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !term.IsTerminal(os.Stdin) || !term.IsTerminal(os.Stdout) {
+			return fmt.Errorf("browse needs to be run in a terminal")
+		}
+
+		f, err := openMatchData(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r, err := newRanking()
+		if err != nil {
+			return err
+		}
+
+		if err = readMatchData(f, r); err != nil {
+			return err
+		}
+
+		return browse.Run(os.Stdin, os.Stdout, r.Model())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+	addInputFlags(browseCmd)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/spf13/cobra"
+)
+
+var diffJSON bool
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff path/to/old.txt path/to/new.txt",
+	Short: "Show what changed between two match data files",
+	Long: `Reads two match data files the same way as the parse command, for example a
+results file and a corrected one from the data provider, then writes what
+changed on each match day: the matches that were removed ( - ), added ( + ) or
+changed ( ~ ), followed by every team whose position or points at the end of
+the day moved as a result. Matches are paired up by the two teams playing, so a
+corrected score, status or venue shows as a change. A match that's moved to
+another day shows as a change on the day it's moved to, noting the day it was
+moved from. Match days with no changes aren't written.
+
+The two files can be in different formats, each is read based on its file
+extension unless --input-format is given.
+
+Use --json to write the changes as JSON instead, with the fields day, added,
+removed, changed ( each with old & new, and old_day for a match that's moved )
+and standings ( team, old_position, new_position, old_points & new_points ) for
+every match day that changed. A position of 0 means the team isn't in that
+day's standings.`,
+	Args:                  cobra.ExactArgs(2),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		old, err := readRanking(args[0])
+		if err != nil {
+			return err
+		}
+		updated, err := readRanking(args[1])
+		if err != nil {
+			return err
+		}
+
+		d := games.Diff(old, updated)
+		if diffJSON {
+			return output.WriteJSON(os.Stdout, d)
+		}
+		return output.WriteDiff(os.Stdout, d)
+	},
+}
+
+// readRanking reads all the match data in the named file into a new ranking
+func readRanking(name string) (*games.Ranking, error) {
+	md, err := openMatchData(name)
+	if err != nil {
+		return nil, err
+	}
+	defer md.Close()
+
+	r, err := newRanking()
+	if err != nil {
+		return nil, err
+	}
+	if err = readMatchData(md, r); err != nil {
+		return nil, err
+	}
+	r.Finish()
+	return r, nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	addInputFlags(diffCmd)
+
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "write the changes as JSON instead of text")
+}
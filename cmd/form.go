@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/spf13/cobra"
+)
+
+var formFormat string
+var formLocale string
+var formMatches int
+var formDay int
+
+// formCmd represents the form command
+var formCmd = &cobra.Command{
+	Use:   "form path/to/match-data.txt",
+	Short: "Show the form table, ranking teams by their last few results",
+	Long: `Reads match data the same way as the parse command, then writes the form
+table: every team ranked by the points earned in its last few results, with
+those results from oldest to newest as W ( won ), D ( drawn ) or L ( lost ).
+Teams level on points are ordered by name.
+
+Use --matches to change how many results are counted, the default is 5. Teams
+that haven't played that many yet count the ones they have. Postponed,
+abandoned and voided matches aren't results, so the form looks past them;
+forfeits count with their awarded score, and point adjustments aren't counted.
+
+The table is for the last match day unless --day is given, for example to
+publish it every week of a season that's already over:
+  form --day 12 results.txt`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := output.ParseFormat(formFormat)
+		if err != nil {
+			return err
+		}
+		loc, err := locale.Get(formLocale)
+		if err != nil {
+			return err
+		}
+
+		md, err := openMatchData(args[0])
+		if err != nil {
+			return err
+		}
+		defer md.Close()
+
+		r, err := newRanking()
+		if err != nil {
+			return err
+		}
+		if err = readMatchData(md, r); err != nil {
+			return err
+		}
+
+		day := formDay
+		if day == 0 {
+			days := r.Model().Days
+			if len(days) == 0 {
+				return nil
+			}
+			day = days[len(days)-1].Number
+		}
+		form, err := r.Form(day, formMatches)
+		if err != nil {
+			return err
+		}
+		return output.WriteForm(os.Stdout, f, loc, day, form)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(formCmd)
+	addInputFlags(formCmd)
+
+	formCmd.Flags().StringVar(&formFormat, "format", string(output.Text), "output format: text, gfm ( GitHub flavored Markdown ), markdown ( plain pipe tables ), or rst ( reStructuredText )")
+	formCmd.Flags().StringVar(&formLocale, "locale", locale.Default, "language for the output: "+strings.Join(locale.Tags(), ", "))
+	formCmd.Flags().IntVar(&formMatches, "matches", games.DefaultFormMatches, "number of results to count for each team")
+	formCmd.Flags().IntVar(&formDay, "day", 0, "match day to show the form table for, the last one if not given")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/input"
+	"github.com/spf13/cobra"
+)
+
+var aliasFile string
+var normalize []string
+var inputFormat string
+var csvColumns []string
+
+// addInputFlags adds the flags that control how match data is read
+func addInputFlags(c *cobra.Command) {
+	c.Flags().StringVar(&aliasFile, "aliases", "", "file mapping team name aliases to canonical names, one '<alias> = <canonical name>' per line")
+	c.Flags().StringSliceVar(&normalize, "normalize", []string{}, "differences to ignore when matching team names: case, space, punct")
+	c.Flags().StringVar(&inputFormat, "input-format", "", "format of the match data: text, csv, jsonl, yaml (default is based on the file extension)")
+	c.Flags().StringSliceVar(&csvColumns, "csv-columns", []string{}, "CSV column for each field, like 'team1=home,score1=home_goals'")
+}
+
+// openMatchData checks that the path is a file and opens it
+func openMatchData(fName string) (*os.File, error) {
+	info, err := os.Stat(fName)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("file %v does not exist", fName)
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("given path is a directory, need a file")
+	}
+
+	f, err := os.OpenFile(fName, os.O_RDONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %w", err)
+	}
+	return f, nil
+}
+
+// newRanking creates a ranking using the name normalization
+// and aliases from the input flags
+func newRanking() (*games.Ranking, error) {
+	r := games.NewRanking()
+	subscribeWebhooks(r)
+
+	norm := games.Normalization{}
+	for _, n := range normalize {
+		switch n {
+		case "case":
+			norm.FoldCase = true
+		case "space":
+			norm.Whitespace = true
+		case "punct":
+			norm.Punctuation = true
+		default:
+			return nil, fmt.Errorf("unknown normalization '%v', expected one of: case, space, punct", n)
+		}
+	}
+	r.SetNormalization(norm)
+
+	if aliasFile != "" {
+		af, err := os.Open(aliasFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open alias file: %w", err)
+		}
+		defer af.Close()
+
+		if err = r.LoadAliases(af); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// matchFormat returns the format of the match data, from the input
+// flags or guessed from the file name
+func matchFormat(f *os.File) (input.Format, error) {
+	if inputFormat != "" {
+		return input.ParseFormat(inputFormat)
+	}
+	return input.DetectFormat(f.Name()), nil
+}
+
+// newMatchReader returns a reader for the match data, using the format
+// from the input flags or guessing it from the file name
+func newMatchReader(f *os.File) (input.Reader, error) {
+	return matchReader(f, f)
+}
+
+// matchReader returns a reader for the match data read from in, which
+// is f or a copy of it; f is only used to guess the format
+func matchReader(in io.Reader, f *os.File) (input.Reader, error) {
+	format, err := matchFormat(f)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := input.ParseColumns(csvColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return input.NewReader(in, format, cols)
+}
+
+// readMatchData adds all the matches in the file to the ranking,
+// printing any warnings about team names to stderr
+func readMatchData(f *os.File, r *games.Ranking) error {
+	return eachEntry(f, func(e input.Entry) error { return addEntry(r, e) })
+}
+
+// eachEntry calls fn with every entry in the match data, stopping at
+// the first error
+func eachEntry(f *os.File, fn func(e input.Entry) error) error {
+	rd, err := newMatchReader(f)
+	if err != nil {
+		return err
+	}
+
+	for {
+		e, err := rd.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(e); err != nil {
+			return err
+		}
+	}
+}
+
+// addEntry adds a single entry to the ranking, printing any warnings
+// about team names to stderr
+func addEntry(r *games.Ranking, e input.Entry) error {
+	seen := len(r.Warnings())
+	err := e.AddTo(r)
+	for _, w := range r.Warnings()[seen:] {
+		fmt.Fprintf(os.Stderr, "warning: %v: %v\n", e.Location, w)
+	}
+	if err != nil {
+		return fmt.Errorf("error parsing %v of match data: %w", e.Location, err)
+	}
+	return nil
+}
@@ -22,18 +22,33 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/input"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
 	"github.com/spf13/cobra"
 )
 
 var matchData *os.File
 var ranking *games.Ranking
 
+var templateFile string
+var chartName string
+var outputFormat string
+var localeTag string
+var promotion int
+var relegation int
+var outputFile string
+var watch bool
+
 // parseCmd represents the parse command
 var parseCmd = &cobra.Command{
 	Use:   "parse path/to/match-data.txt",
@@ -42,13 +57,36 @@ var parseCmd = &cobra.Command{
 loser, and a tie is worth 1 point for each team.
 
 The only expected argument is a path to a file that contains match results.
+Files ending in .csv, .jsonl or .yaml are read as CSV, JSON Lines or YAML, use
+--input-format to override this. Each CSV row, JSON object, or YAML list item
+has the fields team1, score1, team2, score2 and an optional status & venue. Use
+--csv-columns to read the fields from differently named CSV columns.
 
+Any other file is read as text.
 The file format is as follows:
  1. Each line represents a match:
    - Each match is on a single line
    - Each match is defined as "<team name> <score>, <team name> <score>"
    - <team name> is a string of any length
    - <score> is the score that team had at the end of the game, as a number
+   - Team names containing commas, or ending in a number, can be wrapped in
+     double quotes: "Brighton & Hove Albion, U23" 2, "1860 Munich 2" 1
+   - Lines can also use "|" to separate the team names and scores instead:
+       Brighton & Hove Albion, U23 | 2 | 1860 Munich 2 | 1
+     A line with a comma is only read this way if it has all three or four
+     "|", so a name like A|B doesn't need quotes in a line with commas.
+   - A line can end with a status marker:
+       P     postponed, the teams don't earn points and scores are optional
+       ABD   abandoned, the score is kept but the teams don't earn points
+       VOID  cancels the most recent result between the two teams, taking
+             away the points they earned from it
+     When using "|" the status marker goes in a fifth field.
+     A postponed or abandoned match that shows up again later without a
+     marker is recorded as rescheduled onto that day.
+   - The first team is the home team. A match on neutral ground ends with
+     "@" and the venue, after any status marker:
+       Team A 1, Team B 1 @ Wembley Stadium
+     Team names containing " @" need to be wrapped in double quotes.
 
  2. The lines should be in date order -- when the program finds a team that has
     already played in a match day it considers that the end of the day and starts
@@ -65,47 +103,159 @@ The file format is as follows:
      Team A 1, Team B 2
      Team A 2, Team D 1
      Team C 1, Team B 0
-    Would produce two days, but day one would have just a single match ( A vs B ).`,
+    Would produce two days, but day one would have just a single match ( A vs B ).
+
+ 3. Lines starting with "!" are administrative directives:
+     !deduct "Team A" 3 "fielded an ineligible player"
+       Deducts 3 points from Team A starting with the current match day.
+       Add "from <day>" to the end to start the deduction on a specific day.
+     !forfeit "Team A" "Team B" "Team B did not show"
+       Awards the match to Team A 3-0 in the current match day.
+     !remove "Team A" "Team B" from 2
+       Removes the match between Team A and Team B on day 2, as if it had
+       never been added. The repl's :save writes these for deleted matches.
+    The reason is optional for deductions and forfeits. Adjusted points are shown with
+    an annotation in the output.
+
+Use --template to format the output with a Go text/template ( or html/template
+if the file ends in .html ). The template is given a games.Season, which has
+the fields:
+  .Teams   names of every team
+  .Days    each match day, with the fields:
+    .Number     the match day number
+    .Matches    each match: .Team1 .Score1 .Team2 .Score2 .Status .Forfeit
+                .RescheduledFrom .Venue
+    .Standings  every team in order: .Position .Team .Points .Note .Race
+                .Schedule
+    .Leaders n  the top n teams in the standings
+.HomeTable and .AwayTable are the home & away tables, see --venue-tables.
+The functions "pts", "plural", "add" and "join" are also available, see
+testdata/templates for examples.
+
+Use --locale to write the text and markup output in another language, the
+supported locales are en, es, fr, pt ( Brazilian Portuguese ) and pt-PT.
+
+When the output is a terminal the text output shows the full standings for each
+match day as a table, with arrows showing how each team moved since the previous
+match day. Use --promotion and --relegation to highlight the teams at the top
+and bottom of the table. The original plain text is used instead when the output
+isn't a terminal, or the NO_COLOR environment variable is set.
+
+The text, markup and terminal output is written a match day at a time, as
+soon as each day is complete, and days are forgotten once they're written, so
+very long files don't need to fit in memory. Charts and templates need the
+whole season, so they're written once all the match data has been read.
+A voided result or a directive with "from <day>" can change a day that's
+already been written; the match data is then read again up to that line, and
+the changed day and every day after it are written again. Match data that
+can't be read twice, like a pipe, is copied to a temporary file as it's read.
+
+Use --fixtures to give the matches still to be played, one per line written like
+a match line without the scores, "Team A, Team B". The standings for the last
+match day then mark each team that's clinched the title with "c-" in front of
+its name, and each team that can no longer win it with "e-". Use --clinch to
+mark the race for the top few places instead of the title, like --clinch 4. A
+team is only marked if it's certain, whatever the results of the remaining
+matches; teams level on points are ordered by name, as in the standings. In
+templates the marker is .Race.Marker on each standing.
+
+Use --venue-tables to write a home table and an away table after the results,
+the standings counting only the matches each team played at home, or only the
+ones played away. Matches on neutral ground, forfeits and point adjustments
+aren't in either table. The results are then written once all the match data
+has been read, the same as charts and templates.
+
+Use --schedule to add each team's strength of schedule to the standings for the
+last match day: the average current points of the opponents it's played, and
+with --fixtures the average for the ones it still has to play. Postponed,
+abandoned and voided matches don't count as played. It's shown in the final
+table of the markup formats and the terminal tables; the plain text only lists
+the leaders for each day, so a final table listing every team is added after
+them. In templates it's .Schedule on each standing, with .Played .Past
+.Remaining and .Future.
+
+Use --json to write the whole season as JSON instead of text, with the matches
+and standings for every match day, including the race markers and strength of
+schedule when they're asked for.
+
+--chart, --template and --json each replace the text output, so only one of
+them can be given.
+
+Use --output to write the results to a file instead of stdout. The file is
+replaced in one step, so a browser or display reading it never sees half of it.
+
+Use --watch to keep running and update the results whenever the match data file
+changes. Lines added to the end of a text or JSON Lines file are read as they
+show up, once they end with a newline; any other change, or any other format,
+reads the whole file again.
+Lines that can't be parsed are reported on stderr and skipped, so a typo
+doesn't stop the updates. In a terminal the screen is cleared before each
+update; with --output the file is rewritten instead, for example:
+  parse --watch --template board.html --output /srv/board/index.html results.txt
+
+Use --webhook to POST a JSON payload to a URL when a match day closes or the
+leader changes, for example to post updates to a chat channel. The payload has
+the fields event ( "day_closed" or "leader_changed" ), day, standings for
+day_closed, and leader, previous & points for leader_changed. The event is also
+in the X-Rankings-Event header. With --webhook-secret, or $RANKINGS_WEBHOOK_SECRET,
+each payload is signed and the X-Rankings-Signature header is "sha256=" followed
+by the hex HMAC-SHA256 of the body.
+Deliveries that fail with a 5xx or 429 response, or no response at all, are tried
+again with a longer wait each time, up to --webhook-attempts times. Deliveries
+that still fail are reported on stderr and appended to --webhook-dead-letter if
+it's given. When the program exits it waits up to 30 seconds for deliveries
+still being made, any left after that are given up on and treated the same way.
+A match day closes when the next one starts, or when the whole file
+has been read without --watch. With --watch a payload that's already been sent
+isn't sent again when the file is read again from the start.`,
 	Args:                  cobra.ExactArgs(1),
 	DisableFlagsInUseLine: true,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		fName := args[0]
-		info, err := os.Stat(fName)
-		if os.IsNotExist(err) {
-			return fmt.Errorf("file %v does not exist", fName)
+		if err := checkOutputFlags(cmd); err != nil {
+			return err
 		}
 
-		if info.IsDir() {
-			return fmt.Errorf("given path is a directory, need a file")
+		if err := startWebhooks(); err != nil {
+			return err
 		}
 
-		matchData, err = os.OpenFile(fName, os.O_RDONLY, 0644)
-		if err != nil {
-			return fmt.Errorf("unable to open file: %w", err)
+		if err := loadFixtures(); err != nil {
+			return err
 		}
 
-		ranking = games.NewRanking()
+		var err error
+		matchData, err = openMatchData(args[0])
+		if err != nil {
+			return err
+		}
 
-		return nil
+		ranking, err = newRanking()
+		return err
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		r := bufio.NewReader(matchData)
+		// deferred so queued deliveries are made or dead lettered even
+		// when the match data has an error
+		defer stopWebhooks()
 
-		line, _, err := r.ReadLine()
-		for ln := 0; err == nil; line, _, err = r.ReadLine() {
-			ex := ranking.AddMatch(string(line))
-			if ex != nil {
-				return fmt.Errorf("error parsing line %v of match data: %w", ln, ex)
-			}
-			ln++
+		if watch {
+			return watchMatchData(args[0])
 		}
 
-		if err != nil && err != io.EOF {
-			return fmt.Errorf("error processing match data: %w", err)
+		if chartName != "" || templateFile != "" || venueTables || showSchedule || jsonOutput {
+			// charts, templates, JSON, the home and away tables & the
+			// strength of schedule need the whole season at once
+			if err := readMatchData(matchData, ranking); err != nil {
+				return err
+			}
+			ranking.Finish()
+			return writeOutput(func(w io.Writer, terminal bool) error {
+				return writeResults(w, ranking, terminal)
+			})
 		}
 
-		fmt.Printf("%v", ranking.Results())
-		return nil
+		return writeOutput(func(w io.Writer, terminal bool) error {
+			return streamResults(w, ranking, terminal)
+		})
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
 		if err := matchData.Close(); err != nil {
@@ -115,6 +265,248 @@ The file format is as follows:
 	},
 }
 
+// outputFlags are the flags that each replace the text output with
+// something else, so only one of them can be given
+var outputFlags = []string{"chart", "template", "json"}
+
+// checkOutputFlags returns an error if more than one of outputFlags is set
+func checkOutputFlags(cmd *cobra.Command) error {
+	set := []string{}
+	for _, n := range outputFlags {
+		if cmd.Flags().Changed(n) {
+			set = append(set, "--"+n)
+		}
+	}
+	if len(set) > 1 {
+		return fmt.Errorf("%v can't be used together, choose one", strings.Join(set, ", "))
+	}
+	return nil
+}
+
+// writeOutput calls write with the output file if there is one, or stdout
+// if there isn't. terminal is true if the colored terminal tables should
+// be used.
+func writeOutput(write func(w io.Writer, terminal bool) error) error {
+	if outputFile == "" {
+		return write(os.Stdout, useTerminalOutput(os.Stdout))
+	}
+
+	// write to a temporary file first, so anything reading the output
+	// file never sees it half written
+	tmp, err := ioutil.TempFile(filepath.Dir(outputFile), "."+filepath.Base(outputFile)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to create output file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err = tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to create output file: %w", err)
+	}
+
+	if err = write(tmp, false); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write output file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), outputFile); err != nil {
+		return fmt.Errorf("unable to write output file: %w", err)
+	}
+	return nil
+}
+
+// writeResults writes the results in the format chosen by the flags,
+// using the colored terminal tables if terminal is true
+func writeResults(w io.Writer, r *games.Ranking, terminal bool) error {
+	m := r.Model()
+	races, err := raceMarkers(r)
+	if err != nil {
+		return err
+	}
+	if races != nil && len(m.Days) > 0 {
+		last := len(m.Days) - 1
+		m.Days[last] = m.Days[last].WithRaces(races)
+	}
+	schedules, err := scheduleStrengths(r)
+	if err != nil {
+		return err
+	}
+	if schedules != nil && len(m.Days) > 0 {
+		last := len(m.Days) - 1
+		m.Days[last] = m.Days[last].WithSchedules(schedules)
+	}
+
+	if jsonOutput {
+		return output.WriteJSON(w, m)
+	}
+
+	if chartName != "" {
+		c, err := output.ParseChart(chartName)
+		if err != nil {
+			return err
+		}
+		return output.WriteChart(w, c, m)
+	}
+
+	if templateFile != "" {
+		return output.RenderTemplate(w, templateFile, m)
+	}
+
+	dw, err := newDayWriter(w, terminal)
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Days {
+		if err = dw.WriteDay(d); err != nil {
+			return err
+		}
+	}
+	if err = dw.Close(); err != nil {
+		return err
+	}
+	return writeVenueTables(w, m, terminal)
+}
+
+// streamResults reads the match data into the ranking, writing the
+// results for each match day as soon as the day is complete. Days are
+// forgotten once they've been written, so long files don't use up memory.
+func streamResults(w io.Writer, r *games.Ranking, terminal bool) error {
+	dw, err := newDayWriter(w, terminal)
+	if err != nil {
+		return err
+	}
+
+	src, err := newReplaySource(matchData)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	rd, err := matchReader(src, matchData)
+	if err != nil {
+		return err
+	}
+
+	// the races are only known once all the match data has been read,
+	// which is when the last day is written
+	var races map[string]games.Race
+	st := games.NewStream(r, func(d games.Day) error {
+		if races != nil {
+			d = d.WithRaces(races)
+		}
+		return dw.WriteDay(d)
+	})
+	for n := 0; ; n++ {
+		e, err := rd.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = addEntry(r, e)
+		var wd *games.WrittenDayError
+		if errors.As(err, &wd) {
+			r, err = replay(st, src, n, wd.Day, e)
+		}
+		if err != nil {
+			return err
+		}
+		if err = st.Flush(); err != nil {
+			return err
+		}
+	}
+
+	r.Finish()
+	if races, err = raceMarkers(r); err != nil {
+		return err
+	}
+	if err = st.Close(); err != nil {
+		return err
+	}
+	return dw.Close()
+}
+
+// replay starts the stream over with a new ranking when entry number n,
+// e, changes a day that's already been written. The first n entries are
+// read again, keeping back the days from the changed one on, then e is
+// added and those days are written again.
+func replay(st *games.Stream, src *replaySource, n, day int, e input.Entry) (*games.Ranking, error) {
+	r, err := newRanking()
+	if err != nil {
+		return nil, err
+	}
+	st.Restart(r, day)
+
+	rd, err := matchReader(src.Again(), matchData)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		prev, err := rd.Next()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read match data again: %w", err)
+		}
+		// any warnings were printed the first time through
+		if err = prev.AddTo(r); err != nil {
+			return nil, fmt.Errorf("error parsing %v of match data: %w", prev.Location, err)
+		}
+		if err = st.Flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = e.AddTo(r); err != nil {
+		return nil, fmt.Errorf("error parsing %v of match data: %w", e.Location, err)
+	}
+	return r, st.Resume()
+}
+
+// newDayWriter returns a day writer for the output format & locale chosen
+// by the flags, using the colored terminal tables if terminal is true
+func newDayWriter(w io.Writer, terminal bool) (output.DayWriter, error) {
+	f, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := locale.Get(localeTag)
+	if err != nil {
+		return nil, err
+	}
+	if f != output.Text {
+		return output.NewMarkupWriter(w, f, loc)
+	}
+
+	if terminal {
+		opts := output.TerminalOptions{
+			Color:      true,
+			Width:      terminalWidth(os.Stdout),
+			Promotion:  promotion,
+			Relegation: relegation,
+		}
+		return output.NewTerminalWriter(w, loc, opts), nil
+	}
+	return output.NewTextWriter(w, loc), nil
+}
+
 func init() {
 	rootCmd.AddCommand(parseCmd)
+
+	addInputFlags(parseCmd)
+	addWebhookFlags(parseCmd)
+
+	parseCmd.Flags().StringVar(&outputFormat, "format", string(output.Text), "output format: text, gfm ( GitHub flavored Markdown ), markdown ( plain pipe tables ), or rst ( reStructuredText )")
+	parseCmd.Flags().StringVar(&localeTag, "locale", locale.Default, "language for the text and markup output: "+strings.Join(locale.Tags(), ", "))
+	parseCmd.Flags().IntVar(&promotion, "promotion", 0, "number of teams at the top of the table to highlight when writing to a terminal")
+	parseCmd.Flags().IntVar(&relegation, "relegation", 0, "number of teams at the bottom of the table to highlight when writing to a terminal")
+	parseCmd.Flags().StringVar(&chartName, "chart", "", "output an SVG chart instead of text: bump ( table positions ) or points")
+	parseCmd.Flags().StringVar(&templateFile, "template", "", "render the results with a Go template file, files ending in .html use html/template")
+	parseCmd.Flags().StringVarP(&outputFile, "output", "o", "", "write the results to a file instead of stdout, the file is replaced in one step")
+	parseCmd.Flags().StringVar(&fixturesFile, "fixtures", "", "file of the matches left to play, one '<team 1>, <team 2>' per line, to mark teams that have clinched or been eliminated")
+	parseCmd.Flags().IntVar(&clinchPlaces, "clinch", 1, "number of places at the top of the table to mark clinched & eliminated teams for, 1 is the title")
+	parseCmd.Flags().BoolVar(&venueTables, "venue-tables", false, "also write home-only & away-only tables after the results, not used with --chart or --template")
+	parseCmd.Flags().BoolVar(&showSchedule, "schedule", false, "add each team's strength of schedule to the standings for the last match day")
+	parseCmd.Flags().BoolVar(&jsonOutput, "json", false, "write the whole season as JSON instead of text")
+	parseCmd.Flags().BoolVar(&watch, "watch", false, "keep running and update the results whenever the match data file changes")
 }
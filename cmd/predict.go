@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/spf13/cobra"
+)
+
+var predictFormat string
+var predictFixtures string
+var predictNeutral bool
+var predictScores int
+var predictStrengths bool
+var predictCalibration bool
+
+// predictCmd represents the predict command
+var predictCmd = &cobra.Command{
+	Use:   `predict path/to/match-data.txt ["Team A" "Team B"]`,
+	Short: "Predict match results from how many goals each team scores & concedes",
+	Long: `Reads match data the same way as the parse command, then fits a Poisson model
+of the goals each team scores: every team gets an attack strength, how many
+goals it scores, and a defense strength, how few it concedes, along with one
+home advantage for the whole league. Each team is given one imaginary match
+against an average team, so a couple of lucky results don't count for too much.
+Postponed, abandoned and voided matches, and forfeits, aren't used.
+
+Give two team names to predict the match between them, with the first team at
+home unless --neutral is given:
+  predict results.txt "Aptos FC" "Monterey United"
+The prediction is the chance of a win, draw or loss for the first team, the
+goals each team is expected to score, and the most likely scorelines.
+
+Use --fixtures to predict every match in a fixtures file instead, one match per
+line written like a match line without the scores, "Team A, Team B".
+
+Use --strengths to also write each team's attack & defense; 1 is average, and
+higher is better for both.
+
+Use --calibration to check how good the predictions would have been: every
+match from the second match day on is predicted using only the days before it,
+then compared with the result. The report has how often the most likely result
+happened, the Brier score ( 0.667 for guessing, lower is better ), the log loss
+( 1.099 for guessing, lower is better ), and how often results happened for
+each range of predicted chances.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case len(args) == 3:
+			return nil
+		case len(args) != 1:
+			return fmt.Errorf("expected the match data file and either two team names or --fixtures, got %v arguments", len(args))
+		case predictFixtures == "" && !predictStrengths && !predictCalibration:
+			return fmt.Errorf("expected two team names, or one of --fixtures, --strengths or --calibration")
+		}
+		return nil
+	},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if predictScores < 1 {
+			return fmt.Errorf("--scores must be at least 1, got %v", predictScores)
+		}
+
+		f, err := output.ParseFormat(predictFormat)
+		if err != nil {
+			return err
+		}
+
+		matches := []games.Fixture{}
+		if len(args) == 3 {
+			matches = append(matches, games.Fixture{Team1: args[1], Team2: args[2]})
+		}
+		if predictFixtures != "" {
+			fx, err := readFixturesFile(predictFixtures)
+			if err != nil {
+				return err
+			}
+			matches = append(matches, fx...)
+		}
+
+		md, err := openMatchData(args[0])
+		if err != nil {
+			return err
+		}
+		defer md.Close()
+
+		r, err := newRanking()
+		if err != nil {
+			return err
+		}
+		if err = readMatchData(md, r); err != nil {
+			return err
+		}
+
+		p, err := r.FitPoisson(0)
+		if err != nil {
+			return err
+		}
+
+		preds := []games.Prediction{}
+		for _, m := range matches {
+			t1, ok1 := r.TeamName(m.Team1)
+			t2, ok2 := r.TeamName(m.Team2)
+			if !ok1 || !ok2 {
+				return fmt.Errorf("unknown team in '%v, %v'", m.Team1, m.Team2)
+			}
+			pr, err := p.Predict(t1, t2, predictNeutral)
+			if err != nil {
+				return err
+			}
+			preds = append(preds, pr)
+		}
+
+		sections := []func(w io.Writer) error{}
+		if len(preds) > 0 {
+			sections = append(sections, func(w io.Writer) error { return output.WritePredictions(w, f, preds, predictScores) })
+		}
+		if predictStrengths {
+			sections = append(sections, func(w io.Writer) error { return output.WriteStrengths(w, f, p) })
+		}
+		if predictCalibration {
+			c, err := r.Calibrate()
+			if err != nil {
+				return err
+			}
+			sections = append(sections, func(w io.Writer) error { return output.WriteCalibration(w, f, c) })
+		}
+
+		for i, write := range sections {
+			if i > 0 {
+				fmt.Fprintln(os.Stdout)
+			}
+			if err = write(os.Stdout); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(predictCmd)
+	addInputFlags(predictCmd)
+
+	predictCmd.Flags().StringVar(&predictFormat, "format", string(output.Text), "output format: text, gfm ( GitHub flavored Markdown ), markdown ( plain pipe tables ), or rst ( reStructuredText )")
+	predictCmd.Flags().StringVar(&predictFixtures, "fixtures", "", "file of matches to predict, one '<team 1>, <team 2>' per line")
+	predictCmd.Flags().BoolVar(&predictNeutral, "neutral", false, "predict the matches as if they're on neutral ground, instead of the first team at home")
+	predictCmd.Flags().IntVar(&predictScores, "scores", 3, "number of the most likely scorelines to list for each match")
+	predictCmd.Flags().BoolVar(&predictStrengths, "strengths", false, "also write each team's attack & defense strength")
+	predictCmd.Flags().BoolVar(&predictCalibration, "calibration", false, "also check the predictions the model would have made before each match day against the results")
+}
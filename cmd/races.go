@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+var fixturesFile string
+var clinchPlaces int
+
+// fixtures are the matches left to play, read from the fixtures file
+// by loadFixtures. It's nil if there isn't a fixtures file.
+var fixtures []games.Fixture
+
+// loadFixtures reads the fixtures file, if one was given
+func loadFixtures() error {
+	if fixturesFile == "" {
+		return nil
+	}
+	if clinchPlaces < 1 {
+		return fmt.Errorf("--clinch must be at least 1, got %v", clinchPlaces)
+	}
+
+	var err error
+	fixtures, err = readFixturesFile(fixturesFile)
+	return err
+}
+
+// readFixturesFile reads the fixtures in the named file
+func readFixturesFile(name string) ([]games.Fixture, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open fixtures file: %w", err)
+	}
+	defer f.Close()
+
+	return games.ReadFixtures(f)
+}
+
+// raceMarkers works out which teams have clinched a top place or been
+// eliminated from one, it returns nil if there isn't a fixtures file
+func raceMarkers(r *games.Ranking) (map[string]games.Race, error) {
+	if fixtures == nil {
+		return nil, nil
+	}
+	return r.Races(fixtures, clinchPlaces)
+}
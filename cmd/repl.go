@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/seanhagen/jane-coding-challenge/repl"
+	"github.com/spf13/cobra"
+)
+
+// replCmd represents the repl command
+var replCmd = &cobra.Command{
+	Use:   "repl [path/to/match-data.txt]",
+	Short: "Enter match results one at a time and see the standings as they change",
+	Long: `Starts an interactive session for entering results as games finish. If a file
+is given the matches in it are read first, the same way as the parse command.
+
+Type a match line, like "Team A 1, Team B 2", to add it to the current match
+day. Directives such as !deduct and !forfeit also work. A line that can't be
+added shows an error and is left out, without losing anything entered before it.
+
+Lines starting with ":" are commands:
+  :table                  show the current league table
+  :day [n]                show the results & table for a match day
+  :team <name>            show every match a team has played
+  :undo                   remove the last match or directive
+  :edit <day> <match>     correct a score, like :edit 2 Team A 1, Team B 2
+  :delete <day> <teams>   remove a match, like :delete 2 Team A, Team B
+  :save <file>            save everything so far as match data
+  :help                   list the commands
+  :quit                   end the session, ctrl-d also works
+
+In a terminal the line can be edited with the arrow keys, home & end, and
+earlier lines are brought back with the up arrow. When the input isn't a
+terminal the lines are read as-is, so a script of commands can be piped in.
+
+The --webhook flags work the same as for the parse command, a payload is sent
+when the leader changes and when a match day closes, which is when the first
+match of the next day is entered.`,
+	Args:                  cobra.MaximumNArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := startWebhooks(); err != nil {
+			return err
+		}
+		defer stopWebhooks()
+
+		loc, err := locale.Get(localeTag)
+		if err != nil {
+			return err
+		}
+
+		opts := output.TerminalOptions{
+			Color:      useTerminalOutput(os.Stdout),
+			Width:      terminalWidth(os.Stdout),
+			Promotion:  promotion,
+			Relegation: relegation,
+		}
+		s, err := repl.NewSession(os.Stdout, newRanking, loc, opts)
+		if err != nil {
+			return err
+		}
+
+		if len(args) > 0 {
+			if err = loadSession(args[0], s); err != nil {
+				return err
+			}
+		}
+
+		return repl.Run(os.Stdin, os.Stdout, s)
+	},
+}
+
+// loadSession adds all the matches in the file to the session
+func loadSession(path string, s *repl.Session) error {
+	f, err := openMatchData(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rd, err := newMatchReader(f)
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	for {
+		e, err := rd.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if err = s.Add(e); err != nil {
+			return fmt.Errorf("%v: %w", e.Location, err)
+		}
+		n++
+	}
+
+	fmt.Printf("read %v entries from %v\n", n, path)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+	addInputFlags(replCmd)
+	addWebhookFlags(replCmd)
+
+	replCmd.Flags().StringVar(&localeTag, "locale", locale.Default, "language for the tables: "+strings.Join(locale.Tags(), ", "))
+	replCmd.Flags().IntVar(&promotion, "promotion", 0, "number of teams at the top of the table to highlight")
+	replCmd.Flags().IntVar(&relegation, "relegation", 0, "number of teams at the bottom of the table to highlight")
+}
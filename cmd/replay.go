@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+// replaySource reads the match data once as it streams, and can read it
+// again from the start when a line changes a day that's already been
+// written. Match data that can't be seeked, like a pipe, is copied to a
+// temporary file as it's read so it can be read again.
+type replaySource struct {
+	io.Reader
+
+	// at is read from to start again, spool is the temporary copy
+	at    io.ReaderAt
+	spool *os.File
+}
+
+// newReplaySource returns a replaySource for the match data file
+func newReplaySource(f *os.File) (*replaySource, error) {
+	if _, err := f.Seek(0, io.SeekCurrent); err == nil {
+		return &replaySource{Reader: f, at: f}, nil
+	}
+
+	spool, err := ioutil.TempFile("", "rankings-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary copy of match data: %w", err)
+	}
+	return &replaySource{Reader: io.TeeReader(f, spool), at: spool, spool: spool}, nil
+}
+
+// Again returns a reader for the match data from the start, it doesn't
+// change where the match data is being read from
+func (rs *replaySource) Again() io.Reader {
+	return io.NewSectionReader(rs.at, 0, math.MaxInt64)
+}
+
+// Close removes the temporary copy, if there is one
+func (rs *replaySource) Close() error {
+	if rs.spool == nil {
+		return nil
+	}
+	rs.spool.Close()
+	return os.Remove(rs.spool.Name())
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+var showSchedule bool
+var jsonOutput bool
+
+// scheduleStrengths works out each team's strength of schedule, using the
+// fixtures file for the matches left if there is one. It returns nil if
+// the strength of schedule wasn't asked for.
+func scheduleStrengths(r *games.Ranking) (map[string]games.Schedule, error) {
+	if !showSchedule {
+		return nil, nil
+	}
+	return r.Schedules(fixtures)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/spf13/cobra"
+)
+
+// siteCmd represents the site command
+var siteCmd = &cobra.Command{
+	Use:   "site path/to/match-data.txt path/to/output-dir",
+	Short: "Generate a static HTML website from match data",
+	Long: `Reads match data the same way as the parse command, then generates a static
+website in the output directory. The directory is created if it doesn't exist,
+and existing pages in it are overwritten.
+
+The site has:
+  index.html          the current league table, with links to every page
+  day-<n>.html        the results & league table for each match day
+  team-<name>.html    each team's results, points & position over the season
+  head-to-head.html   a matrix of the results between every pair of teams
+
+All styling is inline and there are no external assets, so the directory can
+be copied to any web host as-is.`,
+	Args:                  cobra.ExactArgs(2),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := openMatchData(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r, err := newRanking()
+		if err != nil {
+			return err
+		}
+
+		if err = readMatchData(f, r); err != nil {
+			return err
+		}
+
+		if err = output.WriteSite(args[1], r.Model()); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "site written to %v\n", args[1])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(siteCmd)
+	addInputFlags(siteCmd)
+}
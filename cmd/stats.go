@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/output"
+	"github.com/spf13/cobra"
+)
+
+var statsFormat string
+var statsListed int
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats path/to/match-data.txt",
+	Short: "Show goal statistics for each team and the whole league",
+	Long: `Reads match data the same way as the parse command, then writes goal
+statistics for the league and for each team:
+  League                   total goals, home & away goals, home wins, away
+                           wins & draws, clean sheets and failures to score
+  Teams                    played, won, drawn, lost, goals for (GF) & against
+                           (GA), goal difference (GD), goals scored per match
+                           (GF/P), clean sheets (CS), matches without scoring
+                           (FTS) and the percentage of matches drawn (D%)
+  Home & away              each team's record at home and away
+  Team records             each team's biggest win & highest-scoring match
+  Biggest wins             the matches won by the most goals
+  Highest-scoring matches  the matches with the most goals
+
+The first team in each match line is the home team, matches on neutral ground
+( ending with "@ <venue>" ) aren't in the home & away records. Only matches
+that were played count: postponed, abandoned and voided matches don't, and
+neither do forfeits, since the score is awarded rather than scored.`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statsListed < 0 {
+			return fmt.Errorf("--top must be at least 0, got %v", statsListed)
+		}
+
+		f, err := output.ParseFormat(statsFormat)
+		if err != nil {
+			return err
+		}
+
+		md, err := openMatchData(args[0])
+		if err != nil {
+			return err
+		}
+		defer md.Close()
+
+		r, err := newRanking()
+		if err != nil {
+			return err
+		}
+		if err = readMatchData(md, r); err != nil {
+			return err
+		}
+
+		return output.WriteStats(os.Stdout, f, r.Model().Stats(statsListed))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	addInputFlags(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsFormat, "format", string(output.Text), "output format: text, gfm ( GitHub flavored Markdown ), markdown ( plain pipe tables ), or rst ( reStructuredText )")
+	statsCmd.Flags().IntVar(&statsListed, "top", 5, "number of matches to list for the biggest wins & highest-scoring matches")
+}
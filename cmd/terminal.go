@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// useTerminalOutput returns true if the file is a terminal and the
+// NO_COLOR environment variable is empty, see https://no-color.org
+func useTerminalOutput(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(f)
+}
+
+// terminalWidth returns the width of the terminal, using the COLUMNS
+// environment variable if it's set. Zero means the width isn't known.
+func terminalWidth(f *os.File) int {
+	if c, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && c > 0 {
+		return c
+	}
+	w, _, err := term.Size(f)
+	if err != nil {
+		return 0
+	}
+	return w
+}
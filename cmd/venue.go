@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
+)
+
+var venueTables bool
+
+// writeVenueTables writes the home & away tables after the match days, if
+// they were asked for, in the same format as the rest of the output
+func writeVenueTables(w io.Writer, m games.Season, terminal bool) error {
+	if !venueTables || len(m.Days) == 0 {
+		return nil
+	}
+
+	f, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+	loc, err := locale.Get(localeTag)
+	if err != nil {
+		return err
+	}
+
+	if f == output.Text && terminal {
+		opts := output.TerminalOptions{Color: true, Width: terminalWidth(os.Stdout)}
+		return output.WriteTerminalVenueTables(w, loc, m, opts)
+	}
+	return output.WriteVenueTables(w, f, loc, m)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/input"
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// clearScreen moves the cursor to the top left & clears the terminal
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watcher keeps a ranking up to date with a match data file
+type watcher struct {
+	path string
+	f    *os.File
+
+	ranking *games.Ranking
+
+	// follower reads lines as they're added to the file, it's nil
+	// for formats that have to be read again from the start
+	follower *input.Follower
+
+	// problems are the errors from entries that couldn't be added
+	// since the results were last written
+	problems []error
+
+	// written is true once the results have been written at least once
+	written bool
+}
+
+// watchMatchData writes the results, then writes them again every time
+// the match data file changes. It only returns if the file can't be
+// watched any more.
+//
+// Lines added to the end of text & JSON Lines files are added to the
+// ranking as they show up. Any other change reads the whole file again.
+// Entries that can't be added are reported & skipped, rather than
+// stopping the watch.
+func watchMatchData(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	// the directory is watched rather than the file, so that editors
+	// that save by replacing the file are noticed
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch match data: %w", err)
+	}
+	defer fw.Close()
+	if err = fw.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("unable to watch match data: %w", err)
+	}
+
+	w := &watcher{path: path, f: matchData}
+	if err = w.reload(); err != nil {
+		return err
+	}
+	if err = w.write(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) != path {
+				continue
+			}
+
+			changed := false
+			switch {
+			case ev.Op&fsnotify.Create == fsnotify.Create:
+				// if the new file can't be read, keep showing the old
+				// results until it can
+				if rerr := w.reopen(); rerr != nil {
+					w.problems = append(w.problems, rerr)
+				}
+				changed = true
+			case ev.Op&fsnotify.Write == fsnotify.Write:
+				changed, err = w.update()
+			}
+			if err != nil {
+				return err
+			}
+			if changed {
+				if err = w.write(); err != nil {
+					return err
+				}
+			}
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "error watching match data: %v\n", err)
+		}
+	}
+}
+
+// reopen opens the file again after it's been replaced
+func (w *watcher) reopen() error {
+	f, err := openMatchData(w.path)
+	if err != nil {
+		return err
+	}
+	w.f.Close()
+	w.f, matchData = f, f
+	return w.reload()
+}
+
+// reload reads the whole file into a new ranking
+func (w *watcher) reload() error {
+	r, err := newRanking()
+	if err != nil {
+		return err
+	}
+	w.ranking, w.follower = r, nil
+
+	format, err := matchFormat(w.f)
+	if err != nil {
+		return err
+	}
+	if input.CanFollow(format) {
+		if w.follower, err = input.NewFollower(w.f, format); err != nil {
+			return err
+		}
+		_, err = w.update()
+		return err
+	}
+
+	if _, err = w.f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to read match data: %w", err)
+	}
+	rd, err := newMatchReader(w.f)
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := rd.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			w.problems = append(w.problems, err)
+			return nil
+		}
+		if err = addEntry(w.ranking, e); err != nil {
+			w.problems = append(w.problems, err)
+		}
+	}
+}
+
+// update adds any new lines to the ranking, returning true if the
+// results need to be written again
+func (w *watcher) update() (bool, error) {
+	if w.follower == nil {
+		return true, w.reload()
+	}
+
+	entries, err := w.follower.Next()
+	if errors.Is(err, input.ErrTruncated) {
+		return true, w.reload()
+	}
+	if err != nil {
+		w.problems = append(w.problems, err)
+	}
+
+	for _, e := range entries {
+		if err := addEntry(w.ranking, e); err != nil {
+			w.problems = append(w.problems, err)
+		}
+	}
+	return len(entries) > 0 || err != nil, nil
+}
+
+// write writes the results, clearing the screen first when they're
+// going to a terminal, then reports any entries that were skipped
+func (w *watcher) write() error {
+	switch {
+	case outputFile != "":
+	case term.IsTerminal(os.Stdout):
+		fmt.Fprint(os.Stdout, clearScreen)
+	case w.written:
+		// keep each update separate when the output is a pipe or file
+		fmt.Fprintln(os.Stdout)
+	}
+	err := writeOutput(func(out io.Writer, terminal bool) error {
+		return writeResults(out, w.ranking, terminal)
+	})
+	if err != nil {
+		return err
+	}
+	w.written = true
+	if outputFile != "" {
+		fmt.Fprintf(os.Stderr, "%v results written to %v\n", time.Now().Format("15:04:05"), outputFile)
+	}
+
+	for _, p := range w.problems {
+		fmt.Fprintf(os.Stderr, "skipped: %v\n", p)
+	}
+	w.problems = nil
+	return nil
+}
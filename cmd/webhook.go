@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/webhook"
+	"github.com/spf13/cobra"
+)
+
+// webhookSecretEnv is the environment variable the signing secret is read
+// from, so it doesn't have to be given on the command line
+const webhookSecretEnv = "RANKINGS_WEBHOOK_SECRET"
+
+var webhookURLs []string
+var webhookSecret string
+var webhookAttempts int
+var webhookDeadLetter string
+
+// dispatcher sends webhooks for every ranking created by newRanking,
+// it's nil when no webhook URLs were given
+var dispatcher *webhook.Dispatcher
+
+// unsubscribe stops sending the last ranking subscribed's events
+var unsubscribe func()
+
+// addWebhookFlags adds the flags that set up webhooks
+func addWebhookFlags(c *cobra.Command) {
+	c.Flags().StringSliceVar(&webhookURLs, "webhook", []string{}, "URL to POST a JSON payload to when a match day closes or the leader changes, can be given more than once")
+	c.Flags().StringVar(&webhookSecret, "webhook-secret", "", "key to sign webhook payloads with, defaults to $"+webhookSecretEnv)
+	c.Flags().IntVar(&webhookAttempts, "webhook-attempts", webhook.DefaultAttempts, "how many times to try each webhook delivery before giving up")
+	c.Flags().StringVar(&webhookDeadLetter, "webhook-dead-letter", "", "file to append webhook deliveries that fail to, as JSON Lines")
+}
+
+// startWebhooks creates the dispatcher if any webhook URLs were given
+func startWebhooks() error {
+	if len(webhookURLs) == 0 {
+		return nil
+	}
+
+	secret := webhookSecret
+	if secret == "" {
+		secret = os.Getenv(webhookSecretEnv)
+	}
+
+	var err error
+	dispatcher, err = webhook.New(webhook.Config{
+		URLs:       webhookURLs,
+		Secret:     secret,
+		Attempts:   webhookAttempts,
+		Backoff:    webhook.DefaultBackoff,
+		DeadLetter: webhookDeadLetter,
+		Errors:     os.Stderr,
+	})
+	return err
+}
+
+// subscribeWebhooks sends the ranking's events to the webhooks, if
+// there are any, in place of the last ranking subscribed
+func subscribeWebhooks(r *games.Ranking) {
+	if dispatcher == nil {
+		return
+	}
+	if unsubscribe != nil {
+		unsubscribe()
+	}
+	unsubscribe = dispatcher.Subscribe(r)
+}
+
+// webhookWait is the longest to wait for webhook deliveries before exiting
+const webhookWait = 30 * time.Second
+
+// stopWebhooks waits for any webhook deliveries that haven't been made
+// yet, up to webhookWait, so a webhook that's down doesn't keep the
+// program from exiting. Deliveries still waiting after that are written
+// to the dead letter file.
+func stopWebhooks() {
+	if dispatcher == nil {
+		return
+	}
+	if unsubscribe != nil {
+		unsubscribe()
+	}
+
+	if !dispatcher.Stop(webhookWait) {
+		fmt.Fprintf(os.Stderr, "gave up waiting for webhook deliveries after %v\n", webhookWait)
+	}
+	dispatcher, unsubscribe = nil, nil
+}
@@ -0,0 +1,202 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DirectivePrefix marks a line of input as an administrative
+// directive instead of a match result
+const DirectivePrefix = "!"
+
+// ForfeitScore is the score a forfeited match is awarded as,
+// the winner gets this many goals and the loser gets none
+const ForfeitScore = 3
+
+// adjustment is an administrative change to a team's points
+// total, such as a deduction for fielding an ineligible player.
+type adjustment struct {
+	// Day is the first match day the adjustment applies to
+	Day int
+
+	// Points is how many points are added to the team's total,
+	// deductions are stored as negative numbers
+	Points int
+
+	// Reason is the explanation given for the adjustment
+	Reason string
+}
+
+// String returns the annotation used in the output, something
+// like "-3 pts: fielded an ineligible player"
+func (a adjustment) String() string {
+	s := "pts"
+	if a.Points == 1 || a.Points == -1 {
+		s = "pt"
+	}
+
+	out := fmt.Sprintf("%+d %v", a.Points, s)
+	if a.Reason != "" {
+		out = fmt.Sprintf("%v: %v", out, a.Reason)
+	}
+	return out
+}
+
+// parseDirective handles an input line starting with DirectivePrefix. The
+// supported directives are:
+//
+//	!deduct "<team>" <points> ["<reason>"] [from <day>]
+//	!forfeit "<winner>" "<loser>" ["<reason>"]
+//	!remove "<team 1>" "<team 2>" from <day>
+//
+// Deductions apply from the given day, or from the current match
+// day if no day is given. Forfeits are recorded as a 3-0 win in
+// the current match day. Removals take the match off the given day
+// as if it had never been added, see RemoveMatch.
+func (r *Ranking) parseDirective(input string) error {
+	args, err := splitQuoted(strings.TrimPrefix(strings.TrimSpace(input), DirectivePrefix))
+	if err != nil {
+		return &DirectiveError{line: input, err: err}
+	}
+	if len(args) == 0 {
+		return &DirectiveError{line: input, err: fmt.Errorf("missing directive name")}
+	}
+
+	switch args[0] {
+	case "deduct":
+		err = r.parseDeduct(args[1:])
+	case "forfeit":
+		err = r.parseForfeit(args[1:])
+	case "remove":
+		err = r.parseRemove(args[1:])
+	default:
+		err = fmt.Errorf("unknown directive '%v'", args[0])
+	}
+
+	if err != nil {
+		if _, ok := err.(*TeamPlayedError); ok {
+			// _addMatch needs to see this one so it can start a new day
+			return err
+		}
+		return &DirectiveError{line: input, err: err}
+	}
+	return nil
+}
+
+// parseDeduct handles the arguments to a "!deduct" directive
+func (r *Ranking) parseDeduct(args []string) error {
+	day := r.getCurrentMatchDay().Day
+	if l := len(args); l >= 2 && args[l-2] == "from" {
+		d, err := strconv.Atoi(args[l-1])
+		if err != nil {
+			return fmt.Errorf("unable to parse '%v' as a match day: %w", args[l-1], err)
+		}
+		if d < StartMatchDay {
+			return fmt.Errorf("invalid day, can't be less than %v", StartMatchDay)
+		}
+		day = d
+		args = args[:l-2]
+	}
+
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("expected a team, points, and an optional reason")
+	}
+
+	pts, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("unable to parse '%v' as points: %w", args[1], err)
+	}
+	if pts < 1 {
+		return fmt.Errorf("invalid points, a deduction must be at least 1, got %v", pts)
+	}
+
+	reason := ""
+	if len(args) == 3 {
+		reason = args[2]
+	}
+
+	return r.deduct(args[0], pts, reason, day)
+}
+
+// parseForfeit handles the arguments to a "!forfeit" directive
+func (r *Ranking) parseForfeit(args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("expected a winning team, a losing team, and an optional reason")
+	}
+	if args[0] == "" || args[1] == "" {
+		return &ParseTeamError{empty: true}
+	}
+
+	reason := ""
+	if len(args) == 3 {
+		reason = args[2]
+	}
+
+	w := &teamResult{team: r.findOrCreateTeam(args[0]), score: ForfeitScore}
+	l := &teamResult{team: r.findOrCreateTeam(args[1]), score: 0}
+	md := r.getCurrentMatchDay()
+	if err := md.processForfeit(w, l, reason); err != nil {
+		return err
+	}
+
+	r.matchRecorded(md, w.team.Name)
+	return nil
+}
+
+// deduct takes points away from the named team starting on the
+// given day. Any standings already recorded on or after that day
+// are updated to include the deduction.
+func (r *Ranking) deduct(name string, pts int, reason string, day int) error {
+	if name == "" {
+		return &ParseTeamError{empty: true}
+	}
+
+	if day < r.forgotten {
+		return &WrittenDayError{Day: day}
+	}
+
+	t := r.findOrCreateTeam(name)
+	r.updateStandings(t, t.adjust(adjustment{Day: day, Points: -pts, Reason: reason}))
+	return nil
+}
+
+// splitQuoted splits the input on whitespace, treating anything
+// between a pair of double quotes as a single field. A backslash
+// inside quotes escapes the next character.
+func splitQuoted(in string) ([]string, error) {
+	out := []string{}
+	var cur strings.Builder
+	inField, inQuote, escaped := false, false, false
+
+	for _, c := range in {
+		switch {
+		case escaped:
+			cur.WriteRune(c)
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+			inField = true
+		case !inQuote && unicode.IsSpace(c):
+			if inField {
+				out = append(out, cur.String())
+				cur.Reset()
+				inField = false
+			}
+		default:
+			cur.WriteRune(c)
+			inField = true
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inField {
+		out = append(out, cur.String())
+	}
+	return out, nil
+}
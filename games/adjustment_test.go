@@ -0,0 +1,158 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_Adjustment_SplitQuoted(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect []string
+		ok     bool
+	}{
+		{`deduct "Team A" 3 "reason"`, []string{"deduct", "Team A", "3", "reason"}, true},
+		{`  deduct   A   3  `, []string{"deduct", "A", "3"}, true},
+		{`forfeit "A \"B\"" C`, []string{"forfeit", `A "B"`, "C"}, true},
+		{`deduct "" 3`, []string{"deduct", "", "3"}, true},
+		{`deduct "Team A 3`, nil, false},
+		{``, []string{}, true},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got, err := splitQuoted(tt.in)
+			if tt.ok != (err == nil) {
+				t.Fatalf("expected okay: %v, got error: %v", tt.ok, err)
+			}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.expect) {
+				t.Errorf("wrong fields, expected %q got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+// rankingCase is some input and the results it gives, or ok is false if
+// adding the input should fail
+type rankingCase struct {
+	inputs []string
+	expect string
+	ok     bool
+}
+
+// directiveCases are the inputs for TestGames_Adjustment_Directives, they're
+// also run through a Stream by TestGames_Stream_Cases
+func directiveCases() []rankingCase {
+	base := []string{
+		"A 1, B 0",
+		"C 1, D 1",
+		"A 2, C 0",
+		"B 1, D 0",
+	}
+
+	return []rankingCase{
+		{
+			// deduction read during day 2 only applies from day 2
+			append(append([]string{}, base...), `!deduct "A" 4 "ineligible player"`),
+			`Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+B, 3 pts
+A, 2 pts (-4 pts: ineligible player)
+C, 1 pt
+`,
+			true,
+		},
+		{
+			// explicit day updates standings that were already recorded
+			append(append([]string{}, base...), `!deduct "A" 3 "financial breach" from 1`),
+			`Matchday 1
+C, 1 pt
+D, 1 pt
+A, 0 pts (-3 pts: financial breach)
+
+Matchday 2
+A, 3 pts (-3 pts: financial breach)
+B, 3 pts
+C, 1 pt
+`,
+			true,
+		},
+		{
+			// deductions for a later day wait until that day is played
+			[]string{`!deduct "B" 1 from 2`, "A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 0"},
+			`Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 6 pts
+B, 2 pts (-1 pt)
+C, 1 pt
+`,
+			true,
+		},
+		{
+			[]string{"A 1, B 0", `!forfeit "D" "C" "no show"`, `!forfeit "A" "D"`},
+			`Matchday 1
+A, 3 pts
+D, 3 pts (awarded 3-0 by forfeit: no show)
+B, 0 pts
+
+Matchday 2
+A, 6 pts (awarded 3-0 by forfeit)
+D, 3 pts
+`,
+			true,
+		},
+		{[]string{`!deduct "A"`}, "", false},
+		{[]string{`!deduct "A" three`}, "", false},
+		{[]string{`!deduct "A" -3`}, "", false},
+		{[]string{`!deduct "A" 0 "no reason"`}, "", false},
+		{[]string{`!deduct "A" 3 "reason" from 0`}, "", false},
+		{[]string{`!forfeit "A"`}, "", false},
+		{[]string{`!relegate "A"`}, "", false},
+		{[]string{`!deduct "A 3`}, "", false},
+	}
+}
+
+func TestGames_Adjustment_Directives(t *testing.T) {
+	for i, x := range directiveCases() {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			var err error
+			for _, in := range tt.inputs {
+				if err = r.AddMatch(in); err != nil {
+					break
+				}
+			}
+
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+				if _, ok := err.(*DirectiveError); !ok {
+					t.Errorf("expected DirectiveError, got %T: %v", err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unable to add input, got error: %v", err)
+			}
+
+			got := r.Results()
+			if tt.expect != got {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
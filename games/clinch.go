@@ -0,0 +1,441 @@
+package games
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Fixture is a match that's still to be played
+type Fixture struct {
+	Team1 string
+	Team2 string
+}
+
+// ReadFixtures reads the fixtures left to play, one per line, written
+// the same as ParseFixture expects:
+//
+//	<team 1>, <team 2>
+//
+// Blank lines and lines starting with '#' are skipped.
+func ReadFixtures(in io.Reader) ([]Fixture, error) {
+	out := []Fixture{}
+	s := bufio.NewScanner(in)
+	for ln := 1; s.Scan(); ln++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		t1, t2, err := ParseFixture(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v of fixtures: expected '<team 1>, <team 2>', got '%v'", ln, line)
+		}
+		out = append(out, Fixture{Team1: t1, Team2: t2})
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read fixtures: %w", err)
+	}
+	return out, nil
+}
+
+// fixtureTeams returns the names the ranking records the fixture's teams
+// under, or an error if either team is unknown or it plays itself
+func (r *Ranking) fixtureTeams(f Fixture) (string, string, error) {
+	n1, ok1 := r.TeamName(f.Team1)
+	n2, ok2 := r.TeamName(f.Team2)
+	switch {
+	case !ok1:
+		return "", "", fmt.Errorf("unknown team '%v' in fixture '%v, %v'", f.Team1, f.Team1, f.Team2)
+	case !ok2:
+		return "", "", fmt.Errorf("unknown team '%v' in fixture '%v, %v'", f.Team2, f.Team1, f.Team2)
+	case n1 == n2:
+		return "", "", fmt.Errorf("team '%v' can't play itself", n1)
+	}
+	return n1, n2, nil
+}
+
+// Race is where a team stands in the race for a place at the top of the
+// table, once the fixtures left to play are taken into account
+type Race int
+
+const (
+	// RaceUnknown means the race hasn't been worked out
+	RaceUnknown Race = iota
+
+	// RaceOpen means the team could still finish in or out of the top places
+	RaceOpen
+
+	// RaceClinched means the team finishes in the top places whatever
+	// the results of the remaining fixtures
+	RaceClinched
+
+	// RaceEliminated means the team can't finish in the top places
+	// whatever the results of the remaining fixtures
+	RaceEliminated
+)
+
+// Marker is the letter printed next to a team in the standings, "c" for
+// clinched & "e" for eliminated, empty if the race is still open
+func (r Race) Marker() string {
+	switch r {
+	case RaceClinched:
+		return "c"
+	case RaceEliminated:
+		return "e"
+	}
+	return ""
+}
+
+// MarshalText writes the race as "open", "clinched" or "eliminated", or
+// an empty string if it hasn't been worked out
+func (r Race) MarshalText() ([]byte, error) {
+	switch r {
+	case RaceOpen:
+		return []byte("open"), nil
+	case RaceClinched:
+		return []byte("clinched"), nil
+	case RaceEliminated:
+		return []byte("eliminated"), nil
+	}
+	return []byte{}, nil
+}
+
+// Races works out which teams have clinched a place in the top n of the
+// table, and which have been eliminated from it, given the fixtures left
+// to play. n is 1 for the title. A team is only clinched or eliminated if
+// it's true for every possible result of the remaining fixtures, with
+// teams level on points ordered by name, the same as the standings.
+//
+// Under three points for a win there's no shortcut that's always exact,
+// so maximum flows are used to rule out whole sets of results at once &
+// whatever they can't decide is searched.
+func (r *Ranking) Races(fixtures []Fixture, n int) (map[string]Race, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of places %v, must be at least 1", n)
+	}
+
+	rt := &raceTable{}
+	for name := range r.Teams {
+		rt.names = append(rt.names, name)
+	}
+	sort.Strings(rt.names)
+	index := map[string]int{}
+	for i, name := range rt.names {
+		index[name] = i
+		rt.points = append(rt.points, r.Teams[name].currentRank())
+	}
+
+	for _, f := range fixtures {
+		n1, n2, err := r.fixtureTeams(f)
+		if err != nil {
+			return nil, err
+		}
+		rt.games = append(rt.games, [2]int{index[n1], index[n2]})
+	}
+
+	out := map[string]Race{}
+	for i, name := range rt.names {
+		switch {
+		case rt.clinched(i, n):
+			out[name] = RaceClinched
+		case rt.eliminated(i, n):
+			out[name] = RaceEliminated
+		default:
+			out[name] = RaceOpen
+		}
+	}
+	return out, nil
+}
+
+// WithRaces returns the day with each team's race set in the standings
+func (d Day) WithRaces(races map[string]Race) Day {
+	st := make([]Standing, len(d.Standings))
+	for i, s := range d.Standings {
+		s.Race = races[s.Team]
+		st[i] = s
+	}
+	d.Standings = st
+	return d
+}
+
+// raceTable is the table & remaining fixtures, with teams as indexes
+// into names so the searches can use slices instead of maps
+type raceTable struct {
+	names  []string
+	points []int
+
+	// games are the remaining fixtures
+	games [][2]int
+}
+
+// ahead returns true if team o finishing on po points would be above
+// team s finishing on ps points
+func (rt *raceTable) ahead(o, po, s, ps int) bool {
+	return po > ps || (po == ps && rt.names[o] < rt.names[s])
+}
+
+// against returns how many of the remaining fixtures the team plays
+// against a team that's in the set, or anyone if the set is nil
+func (rt *raceTable) against(t int, set []bool) int {
+	n := 0
+	for _, g := range rt.games {
+		switch {
+		case g[0] == t && (set == nil || set[g[1]]):
+			n++
+		case g[1] == t && (set == nil || set[g[0]]):
+			n++
+		}
+	}
+	return n
+}
+
+// gamesAmong returns the remaining fixtures between teams in the set
+func (rt *raceTable) gamesAmong(set []bool) [][2]int {
+	out := [][2]int{}
+	for _, g := range rt.games {
+		if set[g[0]] && set[g[1]] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// rivals returns every team other than s that isn't caught by skip, the
+// ones with the most points first since they're the likeliest to matter
+func (rt *raceTable) rivals(s int, skip func(o int) bool) []int {
+	out := []int{}
+	for o := range rt.names {
+		if o != s && !skip(o) {
+			out = append(out, o)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rt.points[out[i]] > rt.points[out[j]] })
+	return out
+}
+
+// eliminated returns true if team s can't finish in the top n. The best
+// s can do is win every match it has left, then it needs every team but
+// n-1 to stay below it, with the teams that are allowed above it winning
+// all their matches against the rest.
+func (rt *raceTable) eliminated(s, n int) bool {
+	best := rt.points[s] + 3*rt.against(s, nil)
+
+	// caps is the most points each team can earn & stay below s
+	caps := make([]int, len(rt.names))
+	for o := range rt.names {
+		caps[o] = best - rt.points[o]
+		if rt.ahead(o, best, s, best) {
+			caps[o]--
+		}
+	}
+
+	rest := rt.rivals(s, func(o int) bool { return caps[o] < 0 })
+	above := n - 1 - (len(rt.names) - 1 - len(rest))
+	if above < 0 {
+		return true
+	}
+	if above >= len(rest) {
+		return false
+	}
+
+	possible := eachSubset(rest, above, len(rt.names), func(free []bool) bool {
+		capped := make([]bool, len(rt.names))
+		teams := []int{}
+		for _, o := range rest {
+			if !free[o] {
+				capped[o] = true
+				teams = append(teams, o)
+			}
+		}
+		return stayUnder(teams, caps, rt.gamesAmong(capped))
+	})
+	return !possible
+}
+
+// clinched returns true if team s can't finish outside the top n. The
+// worst for s is losing every match it has left, then it's only clinched
+// if no n teams can all finish above it, with those teams winning all
+// their matches against the rest.
+func (rt *raceTable) clinched(s, n int) bool {
+	worst := rt.points[s]
+	sSet := make([]bool, len(rt.names))
+	sSet[s] = true
+
+	// needs is how many more points each team needs to finish above s,
+	// after beating s in the matches they have left against it
+	needs := make([]int, len(rt.names))
+	for o := range rt.names {
+		needs[o] = worst - rt.points[o] - 3*rt.against(o, sSet)
+		if !rt.ahead(o, worst, s, worst) {
+			needs[o]++
+		}
+	}
+
+	rest := rt.rivals(s, func(o int) bool { return needs[o] <= 0 })
+	above := n - (len(rt.names) - 1 - len(rest))
+	if above <= 0 {
+		return false
+	}
+	if above > len(rest) {
+		return true
+	}
+
+	possible := eachSubset(rest, above, len(rt.names), func(in []bool) bool {
+		// everyone outside the set, other than s, is beaten
+		out := make([]bool, len(rt.names))
+		for o := range out {
+			out[o] = !in[o] && o != s
+		}
+
+		left := make([]int, len(rt.names))
+		teams := []int{}
+		for _, o := range rest {
+			if in[o] {
+				left[o] = needs[o] - 3*rt.against(o, out)
+				teams = append(teams, o)
+			}
+		}
+		return reachAll(teams, left, rt.gamesAmong(in))
+	})
+	return !possible
+}
+
+// eachSubset calls fn with every subset of the items with k members, as a
+// set over 0 to size-1, until fn returns true. It returns true if fn ever did.
+func eachSubset(items []int, k, size int, fn func(set []bool) bool) bool {
+	set := make([]bool, size)
+	var pick func(from, left int) bool
+	pick = func(from, left int) bool {
+		if left == 0 {
+			return fn(set)
+		}
+		for i := from; i <= len(items)-left; i++ {
+			set[items[i]] = true
+			if pick(i+1, left-1) {
+				return true
+			}
+			set[items[i]] = false
+		}
+		return false
+	}
+	return pick(0, k)
+}
+
+// outcomes are the points each team gets from a match: a draw, a win for
+// the first team & a win for the second
+var outcomes = [][2]int{{1, 1}, {3, 0}, {0, 3}}
+
+// stayUnder returns true if the games can be played so that none of the
+// teams earns more than its cap
+func stayUnder(teams []int, caps []int, games [][2]int) bool {
+	left := map[int]int{}
+	for _, g := range games {
+		left[g[0]]++
+		left[g[1]]++
+	}
+
+	// draws are the fewest points a match can give out, so if every
+	// team can draw all its games there's nothing to search
+	draws := true
+	for _, t := range teams {
+		if caps[t] < 0 {
+			return false
+		}
+		draws = draws && caps[t] >= left[t]
+	}
+	if draws {
+		return true
+	}
+
+	// every match gives out at least 2 points, if they can't be shared
+	// out without going over the caps then nothing will work
+	fn := newFlowNetwork(2 + len(games) + len(caps))
+	for i, g := range games {
+		fn.add(flowSource, 2+i, 2)
+		fn.add(2+i, 2+len(games)+g[0], 2)
+		fn.add(2+i, 2+len(games)+g[1], 2)
+	}
+	for _, t := range teams {
+		fn.add(2+len(games)+t, flowSink, caps[t])
+	}
+	if fn.maxFlow() < 2*len(games) {
+		return false
+	}
+
+	g := games[0]
+	for _, o := range outcomes {
+		if caps[g[0]] < o[0] || caps[g[1]] < o[1] {
+			continue
+		}
+		next := append([]int{}, caps...)
+		next[g[0]] -= o[0]
+		next[g[1]] -= o[1]
+		if stayUnder(teams, next, games[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// reachAll returns true if the games can be played so that every one of
+// the teams earns at least the points it needs
+func reachAll(teams []int, needs []int, games [][2]int) bool {
+	total, wins := 0, 0
+	for _, t := range teams {
+		if needs[t] > 0 {
+			total += needs[t]
+			wins += (needs[t] + 2) / 3
+		}
+	}
+	if total == 0 {
+		return true
+	}
+
+	// a match gives out at most 3 points, if the teams can't get what
+	// they need from that then nothing will work
+	relaxed := newFlowNetwork(2 + len(games) + len(needs))
+	for i, g := range games {
+		relaxed.add(flowSource, 2+i, 3)
+		relaxed.add(2+i, 2+len(games)+g[0], 3)
+		relaxed.add(2+i, 2+len(games)+g[1], 3)
+	}
+	for _, t := range teams {
+		if needs[t] > 0 {
+			relaxed.add(2+len(games)+t, flowSink, needs[t])
+		}
+	}
+	if relaxed.maxFlow() < total {
+		return false
+	}
+
+	// if there are enough wins to go round there's nothing to search
+	won := newFlowNetwork(2 + len(games) + len(needs))
+	for i, g := range games {
+		won.add(flowSource, 2+i, 1)
+		won.add(2+i, 2+len(games)+g[0], 1)
+		won.add(2+i, 2+len(games)+g[1], 1)
+	}
+	for _, t := range teams {
+		if needs[t] > 0 {
+			won.add(2+len(games)+t, flowSink, (needs[t]+2)/3)
+		}
+	}
+	if won.maxFlow() == wins {
+		return true
+	}
+
+	g := games[0]
+	for _, o := range outcomes {
+		next := append([]int{}, needs...)
+		next[g[0]] -= o[0]
+		next[g[1]] -= o[1]
+		if reachAll(teams, next, games[1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package games
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_Races(t *testing.T) {
+	tests := []struct {
+		inputs   []string
+		fixtures []Fixture
+		n        int
+		expect   string
+	}{
+		{
+			// nothing left to play, the table is final
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]Fixture{},
+			1,
+			"A c\nB e\nC e\nD e\n",
+		},
+		{
+			// A can be caught by C & D but not B
+			[]string{"A 3, B 0", "C 1, D 1"},
+			[]Fixture{{"B", "C"}, {"A", "D"}},
+			1,
+			"A \nB e\nC \nD \n",
+		},
+		{
+			// only B can still catch A
+			[]string{"A 1, B 0", "C 0, D 0", "A 1, C 0", "B 1, D 1"},
+			[]Fixture{{"B", "C"}, {"D", "B"}},
+			1,
+			"A \nB \nC e\nD e\n",
+		},
+		{
+			// C only makes the top 2 if it beats B and B draws with D
+			[]string{"A 1, B 0", "C 0, D 0", "A 1, C 0", "B 1, D 1"},
+			[]Fixture{{"B", "C"}, {"D", "B"}},
+			2,
+			"A c\nB \nC \nD \n",
+		},
+		{
+			// more places than teams
+			[]string{"A 1, B 0"},
+			[]Fixture{{"A", "B"}},
+			3,
+			"A c\nB c\n",
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			races, err := r.Races(tt.fixtures, tt.n)
+			if err != nil {
+				t.Fatalf("unable to work out races: %v", err)
+			}
+
+			got := ""
+			for _, name := range r.Model().Teams {
+				got += fmt.Sprintf("%v %v\n", name, races[name].Marker())
+			}
+			if got != tt.expect {
+				t.Errorf("wrong races\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Races_Errors(t *testing.T) {
+	r := NewRanking()
+	if err := r.AddMatch("A 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+
+	tests := []struct {
+		fixtures []Fixture
+		n        int
+	}{
+		{[]Fixture{{"A", "C"}}, 1},
+		{[]Fixture{{"A", "A"}}, 1},
+		{[]Fixture{{"A", "B"}}, 0},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if _, err := r.Races(tt.fixtures, tt.n); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+// TestGames_Races_Exhaustive checks small random tables against every
+// possible result of the remaining fixtures
+func TestGames_Races_Exhaustive(t *testing.T) {
+	rnd := rand.New(rand.NewSource(44))
+	names := []string{"A", "B", "C", "D", "E", "F"}
+
+	for i := 0; i < 300; i++ {
+		rt := &raceTable{names: names, points: make([]int, len(names))}
+		for j := range rt.points {
+			rt.points[j] = rnd.Intn(10)
+		}
+		for j := rnd.Intn(9); j > 0; j-- {
+			a, b := rnd.Intn(len(names)), rnd.Intn(len(names)-1)
+			if b >= a {
+				b++
+			}
+			rt.games = append(rt.games, [2]int{a, b})
+		}
+
+		// best & worst are each team's best & worst finishing position
+		best := make([]int, len(names))
+		worst := make([]int, len(names))
+		for j := range best {
+			best[j] = len(names)
+		}
+		points := make([]int, len(names))
+		var play func(g int)
+		play = func(g int) {
+			if g == len(rt.games) {
+				for s := range names {
+					pos := 1
+					for o := range names {
+						if o != s && rt.ahead(o, points[o], s, points[s]) {
+							pos++
+						}
+					}
+					if pos < best[s] {
+						best[s] = pos
+					}
+					if pos > worst[s] {
+						worst[s] = pos
+					}
+				}
+				return
+			}
+			for _, o := range outcomes {
+				points[rt.games[g][0]] += o[0]
+				points[rt.games[g][1]] += o[1]
+				play(g + 1)
+				points[rt.games[g][0]] -= o[0]
+				points[rt.games[g][1]] -= o[1]
+			}
+		}
+		copy(points, rt.points)
+		play(0)
+
+		for n := 1; n < len(names); n++ {
+			for s := range names {
+				if got, expect := rt.clinched(s, n), worst[s] <= n; got != expect {
+					t.Errorf("%v: team %v clinched top %v is %v, expected %v", describeRace(rt), names[s], n, got, expect)
+				}
+				if got, expect := rt.eliminated(s, n), best[s] > n; got != expect {
+					t.Errorf("%v: team %v eliminated from top %v is %v, expected %v", describeRace(rt), names[s], n, got, expect)
+				}
+			}
+		}
+	}
+}
+
+// describeRace writes out the table & fixtures for a failed test
+func describeRace(rt *raceTable) string {
+	parts := []string{}
+	for i, n := range rt.names {
+		parts = append(parts, fmt.Sprintf("%v=%v", n, rt.points[i]))
+	}
+	for _, g := range rt.games {
+		parts = append(parts, fmt.Sprintf("%v-%v", rt.names[g[0]], rt.names[g[1]]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,195 @@
+package games
+
+import (
+	"sort"
+)
+
+// RankingDiff is everything that changed between two rankings, such as
+// an old results file and a corrected one
+type RankingDiff struct {
+	// Days are the match days that changed, in order
+	Days []DayDiff `json:"days"`
+}
+
+// Same is true if nothing changed
+func (d RankingDiff) Same() bool {
+	return len(d.Days) == 0
+}
+
+// DayDiff is what changed on a single match day. A day that's only in
+// one of the rankings has all of its matches added or removed.
+type DayDiff struct {
+	Day int `json:"day"`
+
+	// Added & Removed are the matches only in the new or old ranking, and
+	// Changed are the ones between the same teams that aren't the same,
+	// like a corrected score
+	Added   []Match       `json:"added,omitempty"`
+	Removed []Match       `json:"removed,omitempty"`
+	Changed []MatchChange `json:"changed,omitempty"`
+
+	// Standings are the teams whose position or points at the end of
+	// the day changed, in the order of the new standings
+	Standings []StandingShift `json:"standings,omitempty"`
+}
+
+// MatchChange is a match between the same two teams that's different in
+// the new ranking, or has moved to another day
+type MatchChange struct {
+	Old Match `json:"old"`
+	New Match `json:"new"`
+
+	// OldDay is the day the match was on in the old ranking if it's
+	// moved, zero if it's on the same day
+	OldDay int `json:"old_day,omitempty"`
+}
+
+// StandingShift is how a team's standing at the end of a day changed. The
+// position is zero if the team isn't in that day's standings.
+type StandingShift struct {
+	Team        string `json:"team"`
+	OldPosition int    `json:"old_position"`
+	NewPosition int    `json:"new_position"`
+	OldPoints   int    `json:"old_points"`
+	NewPoints   int    `json:"new_points"`
+}
+
+// Diff compares two rankings a match day at a time, from is the old
+// ranking & to is the new one. Matches are paired up by the two teams
+// playing, so a match with its teams the other way round is changed rather
+// than removed & added. A match removed from one day & added to another
+// between the same teams is changed too, on the day it's moved to.
+func Diff(from, to *Ranking) RankingDiff {
+	days := map[int][2]Day{}
+	for _, d := range from.Model().Days {
+		days[d.Number] = [2]Day{d, {Number: d.Number}}
+	}
+	for _, d := range to.Model().Days {
+		p := days[d.Number]
+		p[0].Number, p[1] = d.Number, d
+		days[d.Number] = p
+	}
+
+	numbers := []int{}
+	for n := range days {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	diffs := map[int]*DayDiff{}
+	for _, n := range numbers {
+		dd := diffDay(days[n][0], days[n][1])
+		diffs[n] = &dd
+	}
+	pairMoved(numbers, diffs)
+
+	out := RankingDiff{Days: []DayDiff{}}
+	for _, n := range numbers {
+		dd := diffs[n]
+		if len(dd.Added)+len(dd.Removed)+len(dd.Changed)+len(dd.Standings) > 0 {
+			out.Days = append(out.Days, *dd)
+		}
+	}
+	return out
+}
+
+// movedMatch is a match removed from a day, that might have moved
+type movedMatch struct {
+	day   int
+	match Match
+}
+
+// pairMoved pairs up matches removed from one day with matches between the
+// same teams added to another, since the teams can meet more than once a
+// season they're paired up in day order
+func pairMoved(numbers []int, diffs map[int]*DayDiff) {
+	removed := map[[2]string][]movedMatch{}
+	for _, n := range numbers {
+		for _, m := range diffs[n].Removed {
+			k := matchKey(m)
+			removed[k] = append(removed[k], movedMatch{n, m})
+		}
+	}
+
+	moved := map[movedMatch]bool{}
+	for _, n := range numbers {
+		dd := diffs[n]
+		var added []Match
+		for _, m := range dd.Added {
+			k := matchKey(m)
+			if len(removed[k]) == 0 {
+				added = append(added, m)
+				continue
+			}
+			old := removed[k][0]
+			removed[k] = removed[k][1:]
+			moved[old] = true
+			dd.Changed = append(dd.Changed, MatchChange{Old: old.match, New: m, OldDay: old.day})
+		}
+		dd.Added = added
+	}
+
+	for _, n := range numbers {
+		dd := diffs[n]
+		var left []Match
+		for _, m := range dd.Removed {
+			if !moved[movedMatch{n, m}] {
+				left = append(left, m)
+			}
+		}
+		dd.Removed = left
+	}
+}
+
+// diffDay compares the same match day from two rankings
+func diffDay(from, to Day) DayDiff {
+	dd := DayDiff{Day: to.Number}
+
+	olds := map[[2]string]Match{}
+	for _, m := range from.Matches {
+		olds[matchKey(m)] = m
+	}
+	news := map[[2]string]bool{}
+	for _, m := range to.Matches {
+		news[matchKey(m)] = true
+		om, ok := olds[matchKey(m)]
+		switch {
+		case !ok:
+			dd.Added = append(dd.Added, m)
+		case om != m:
+			dd.Changed = append(dd.Changed, MatchChange{Old: om, New: m})
+		}
+	}
+	for _, m := range from.Matches {
+		if !news[matchKey(m)] {
+			dd.Removed = append(dd.Removed, m)
+		}
+	}
+
+	before := map[string]Standing{}
+	for _, s := range from.Standings {
+		before[s.Team] = s
+	}
+	after := map[string]bool{}
+	for _, s := range to.Standings {
+		after[s.Team] = true
+		b := before[s.Team]
+		if b.Position != s.Position || b.Points != s.Points {
+			dd.Standings = append(dd.Standings, StandingShift{s.Team, b.Position, s.Position, b.Points, s.Points})
+		}
+	}
+	for _, s := range from.Standings {
+		if !after[s.Team] {
+			dd.Standings = append(dd.Standings, StandingShift{s.Team, s.Position, 0, s.Points, 0})
+		}
+	}
+	return dd
+}
+
+// matchKey is the two teams in the match, in alphabetical order
+func matchKey(m Match) [2]string {
+	if m.Team2 < m.Team1 {
+		return [2]string{m.Team2, m.Team1}
+	}
+	return [2]string{m.Team1, m.Team2}
+}
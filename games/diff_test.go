@@ -0,0 +1,135 @@
+package games
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGames_Diff(t *testing.T) {
+	played := func(t1 string, s1 int, t2 string, s2 int) Match {
+		return Match{Team1: t1, Score1: s1, Team2: t2, Score2: s2, Status: "played"}
+	}
+
+	tests := []struct {
+		from, to []string
+		expect   []DayDiff
+	}{
+		{
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]DayDiff{},
+		},
+		{
+			// a corrected score moves the teams in the standings
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]string{"A 1, B 2", "C 1, D 1"},
+			[]DayDiff{{
+				Day:     1,
+				Changed: []MatchChange{{played("A", 1, "B", 0), played("A", 1, "B", 2), 0}},
+				Standings: []StandingShift{
+					{"B", 4, 1, 0, 3},
+					{"A", 1, 4, 3, 0},
+				},
+			}},
+		},
+		{
+			// the teams the other way round is a change, not a new match
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]string{"B 0, A 1", "C 1, D 1"},
+			[]DayDiff{{
+				Day:     1,
+				Changed: []MatchChange{{played("A", 1, "B", 0), played("B", 0, "A", 1), 0}},
+			}},
+		},
+		{
+			// a match swapped for another, and a whole day added
+			[]string{"A 1, B 0", "C 1, D 1"},
+			[]string{"A 1, B 0", "C 1, E 1", "A 0, C 0"},
+			[]DayDiff{
+				{
+					Day:     1,
+					Added:   []Match{played("C", 1, "E", 1)},
+					Removed: []Match{played("C", 1, "D", 1)},
+					Standings: []StandingShift{
+						{"E", 0, 3, 0, 1},
+						{"D", 3, 0, 1, 0},
+					},
+				},
+				{
+					Day:   2,
+					Added: []Match{played("A", 0, "C", 0)},
+					Standings: []StandingShift{
+						{"A", 0, 1, 0, 4},
+						{"C", 0, 2, 0, 2},
+					},
+				},
+			},
+		},
+		{
+			// matches moved to another day are changed on the day
+			// they're moved to, rather than removed & added
+			[]string{"A 1, B 0", "C 1, D 1", "A 0, C 0", "B 2, D 2", "A 3, D 0", "B 1, C 1"},
+			[]string{"A 1, B 0", "C 1, D 1", "A 3, D 0", "B 1, C 1", "A 0, C 0", "B 2, D 2"},
+			[]DayDiff{
+				{
+					Day: 2,
+					Changed: []MatchChange{
+						{played("A", 3, "D", 0), played("A", 3, "D", 0), 3},
+						{played("B", 1, "C", 1), played("B", 1, "C", 1), 3},
+					},
+					Standings: []StandingShift{
+						{"A", 1, 1, 4, 6},
+						{"B", 4, 3, 1, 1},
+						{"D", 3, 4, 2, 1},
+					},
+				},
+				{
+					Day: 3,
+					Changed: []MatchChange{
+						{played("A", 0, "C", 0), played("A", 0, "C", 0), 2},
+						{played("B", 2, "D", 2), played("B", 2, "D", 2), 2},
+					},
+				},
+			},
+		},
+		{
+			// a day removed
+			[]string{"A 1, B 0", "A 1, B 1"},
+			[]string{"A 1, B 0"},
+			[]DayDiff{{
+				Day:     2,
+				Removed: []Match{played("A", 1, "B", 1)},
+				Standings: []StandingShift{
+					{"A", 1, 0, 4, 0},
+					{"B", 2, 0, 1, 0},
+				},
+			}},
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			from, to := NewRanking(), NewRanking()
+			for _, in := range tt.from {
+				if err := from.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+			for _, in := range tt.to {
+				if err := to.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			got := Diff(from, to)
+			if !reflect.DeepEqual(got.Days, tt.expect) {
+				t.Errorf("wrong diff\nexpected: %+v\ngot:      %+v", tt.expect, got.Days)
+			}
+			if got.Same() != (len(tt.expect) == 0) {
+				t.Errorf("expected Same to be %v", len(tt.expect) == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TeamName returns the name a team is recorded under once aliases &
+// normalization are applied, and whether the ranking knows the team
+func (r *Ranking) TeamName(name string) (string, bool) {
+	if _, ok := r.Teams[name]; ok {
+		return name, true
+	}
+	n, _ := r.names.canonical(name)
+	_, ok := r.Teams[n]
+	return n, ok
+}
+
+// findMatch looks up the match between the two named teams on the given day
+func (r *Ranking) findMatch(day int, name1, name2 string) (*matchDay, *team, *team, error) {
+	if day < r.forgotten {
+		return nil, nil, nil, &WrittenDayError{Day: day}
+	}
+	n1, ok1 := r.TeamName(name1)
+	n2, ok2 := r.TeamName(name2)
+	md, ok := r.Days[day]
+	if !ok || !ok1 || !ok2 || md.Matchups[n1] != n2 {
+		return nil, nil, nil, &MatchNotFoundError{team1: name1, team2: name2, day: day}
+	}
+	return md, r.Teams[n1], r.Teams[n2], nil
+}
+
+// AmendMatch replaces the score of the match between the two teams in
+// the record on the given day. The standings for that day and every day
+// after it are updated. A match awarded by forfeit becomes a normal
+// result; postponed, abandoned and voided matches can't be amended.
+func (r *Ranking) AmendMatch(day int, mr MatchRecord) error {
+	ml, err := mr.matchLine()
+	if err != nil {
+		return err
+	}
+	if ml.status != statusPlayed {
+		return fmt.Errorf("only the score of a played match can be amended, got status '%v'", ml.status)
+	}
+
+	md, t1, t2, err := r.findMatch(day, ml.team1, ml.team2)
+	if err != nil {
+		return err
+	}
+	if st, ok := md.Statuses[t1.Name]; ok {
+		return fmt.Errorf("the match between '%v' and '%v' on day %v was %v, only played matches can be amended", t1.Name, t2.Name, day, st)
+	}
+
+	s1, _ := strconv.Atoi(ml.score1)
+	s2, _ := strconv.Atoi(ml.score2)
+	r1, r2 := matchWon, matchLost
+	if s1 < s2 {
+		r1, r2 = r2, r1
+	} else if s1 == s2 {
+		r1, r2 = matchTied, matchTied
+	}
+
+	delete(md.Forfeits, t1.Name)
+	delete(md.Forfeits, t2.Name)
+	md.Teams[t1.Name], md.Teams[t2.Name] = s1, s2
+
+	r.updateStandings(t1, t1.rescore(day, s1, r1))
+	r.updateStandings(t2, t2.rescore(day, s2, r2))
+	r.standingsChanged()
+	return nil
+}
+
+// RemoveMatch removes the match between the two teams on the given day,
+// as if it had never been added. Any points either team earned from it
+// are taken away from the standings for that day and every day after.
+//
+// If the match was the last one on the last match day, that day is
+// removed too, so the next match added starts the day over.
+func (r *Ranking) RemoveMatch(day int, team1, team2 string) error {
+	md, t1, t2, err := r.findMatch(day, team1, team2)
+	if err != nil {
+		return err
+	}
+
+	// put back any postponement this match was making up for, or
+	// forget the one it created
+	key := fixtureKey(t1.Name, t2.Name)
+	if d, ok := md.Rescheduled[t1.Name]; ok {
+		r.pending[key] = d
+	} else if st, ok := md.Statuses[t1.Name]; ok && st != statusVoid && r.pending[key] == day {
+		delete(r.pending, key)
+	}
+
+	md.removeMatch(t1.Name, t2.Name)
+	r.updateStandings(t1, t1.unplay(day))
+	r.updateStandings(t2, t2.unplay(day))
+
+	if l := len(r.matches); day == r.currentDay && l > 1 && len(md.Order) == 0 && r.matches[l-2] != nil {
+		delete(r.Days, day)
+		r.matches = r.matches[:l-1]
+		r.currentMatch = r.matches[l-2]
+		r.currentDay = r.currentMatch.Day
+	}
+
+	r.standingsChanged()
+	return nil
+}
+
+// parseRemove handles the arguments to a "!remove" directive
+func (r *Ranking) parseRemove(args []string) error {
+	if len(args) != 4 || args[2] != "from" {
+		return fmt.Errorf("expected two teams and the match day, like \"Team A\" \"Team B\" from 2")
+	}
+	day, err := strconv.Atoi(args[3])
+	if err != nil {
+		return fmt.Errorf("unable to parse '%v' as a match day: %w", args[3], err)
+	}
+	return r.RemoveMatch(day, args[0], args[1])
+}
+
+// RemoveDirective returns the "!remove" directive for the match between
+// the two teams on the given day, so a removal can be written out as
+// match data and applied again
+func RemoveDirective(day int, team1, team2 string) string {
+	q := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return fmt.Sprintf(`%vremove "%v" "%v" from %v`, DirectivePrefix, q.Replace(team1), q.Replace(team2), day)
+}
+
+// updateStandings copies the team's points & adjustments into the
+// match days that changed
+func (r *Ranking) updateStandings(t *team, changed []int) {
+	for _, d := range changed {
+		if md, ok := r.Days[d]; ok {
+			md.updateStanding(t.Name, t.Standing[d], t.adjustmentsOn(d))
+		}
+	}
+}
+
+// ParseFixture parses the two teams in a fixture, written like a match
+// line without the scores: "<team 1>, <team 2>" or "<team 1> | <team 2>".
+// Team names can be quoted.
+func ParseFixture(in string) (string, string, error) {
+	sep := ','
+	if strings.ContainsRune(unquoted(in), MatchDelimiter) {
+		sep = MatchDelimiter
+	}
+
+	parts, err := splitOutsideQuotes(in, sep)
+	if err != nil || len(parts) != 2 {
+		return "", "", &ParseLineError{in}
+	}
+
+	t1, err := unquoteName(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return "", "", err
+	}
+	t2, err := unquoteName(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return "", "", err
+	}
+	return t1, t2, nil
+}
@@ -0,0 +1,321 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_Edit_AmendMatch(t *testing.T) {
+	base := []string{
+		"A 1, B 0",
+		"C 1, D 1",
+		"A 2, C 0",
+		"B 1, D 0",
+	}
+
+	score := func(n int) *int { return &n }
+
+	tests := []struct {
+		inputs []string
+		day    int
+		record MatchRecord
+		expect string
+		ok     bool
+	}{
+		{
+			// a win on day 1 becomes a loss, day 2 is updated too
+			base, 1, MatchRecord{Team1: "A", Score1: score(0), Team2: "B", Score2: score(2)},
+			`Matchday 1
+B, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+B, 6 pts
+A, 3 pts
+C, 1 pt
+`,
+			true,
+		},
+		{
+			// teams can be given in either order
+			base, 2, MatchRecord{Team1: "C", Score1: score(1), Team2: "A", Score2: score(1)},
+			`Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 4 pts
+B, 3 pts
+C, 2 pts
+`,
+			true,
+		},
+		{
+			// a forfeit becomes a normal result
+			[]string{`!forfeit A B "no show"`, "C 1, D 1", "A 2, C 0", "B 1, D 0"},
+			1, MatchRecord{Team1: "A", Score1: score(1), Team2: "B", Score2: score(1)},
+			`Matchday 1
+A, 1 pt
+B, 1 pt
+C, 1 pt
+
+Matchday 2
+A, 4 pts
+B, 4 pts
+C, 1 pt
+`,
+			true,
+		},
+		{
+			base, 2, MatchRecord{Team1: "A", Score1: score(1), Team2: "B", Score2: score(0)},
+			"",
+			false,
+		},
+		{
+			base, 3, MatchRecord{Team1: "A", Score1: score(1), Team2: "B", Score2: score(0)},
+			"",
+			false,
+		},
+		{
+			[]string{"A, B P", "C 1, D 1"}, 1, MatchRecord{Team1: "A", Score1: score(1), Team2: "B", Score2: score(0)},
+			"",
+			false,
+		},
+		{
+			base, 1, MatchRecord{Team1: "A", Team2: "B", Status: "P"},
+			"",
+			false,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			err := r.AmendMatch(tt.day, tt.record)
+			if tt.ok != (err == nil) {
+				t.Fatalf("expected okay: %v, got error: %v", tt.ok, err)
+			}
+			if !tt.ok {
+				return
+			}
+
+			if got := r.Results(); got != tt.expect {
+				t.Errorf("wrong results:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Edit_RemoveMatch(t *testing.T) {
+	base := []string{
+		"A 1, B 0",
+		"C 1, D 1",
+		"A 2, C 0",
+		"B 1, D 0",
+	}
+
+	tests := []struct {
+		inputs []string
+		day    int
+		team1  string
+		team2  string
+		after  []string
+		expect string
+		ok     bool
+	}{
+		{
+			// removing a match from an earlier day takes the points
+			// away from every day after it
+			base, 1, "B", "A", nil,
+			`Matchday 1
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 3 pts
+B, 3 pts
+C, 1 pt
+`,
+			true,
+		},
+		{
+			// the removed match can be added again with the right score
+			base, 2, "B", "D", []string{"D 1, B 0"},
+			`Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 6 pts
+D, 4 pts
+C, 1 pt
+`,
+			true,
+		},
+		{
+			// removing the only match on the last day removes the day
+			[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0"}, 2, "A", "C", []string{"A 0, D 2"},
+			`Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+D, 4 pts
+A, 3 pts
+`,
+			true,
+		},
+		{
+			// removing a rescheduled match puts the postponement back
+			[]string{"A, B P", "C 1, D 1", "A 1, B 0", "C 0, D 0"}, 2, "A", "B", []string{"A 2, B 2"},
+			`Matchday 1
+C, 1 pt
+D, 1 pt
+A, 0 pts
+
+Matchday 2
+C, 2 pts
+D, 2 pts
+A, 1 pt
+`,
+			true,
+		},
+		{
+			base, 1, "A", "C", nil,
+			"",
+			false,
+		},
+		{
+			base, 1, "A", "Z", nil,
+			"",
+			false,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			err := r.RemoveMatch(tt.day, tt.team1, tt.team2)
+			if tt.ok != (err == nil) {
+				t.Fatalf("expected okay: %v, got error: %v", tt.ok, err)
+			}
+			if !tt.ok {
+				return
+			}
+
+			for _, in := range tt.after {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v' after removing: %v", in, err)
+				}
+			}
+
+			if got := r.Results(); got != tt.expect {
+				t.Errorf("wrong results:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Edit_RemoveDirective(t *testing.T) {
+	tests := []struct {
+		inputs []string
+		expect string
+		ok     bool
+	}{
+		{
+			[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 1", RemoveDirective(1, "A", "B")},
+			`Matchday 1
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 3 pts
+D, 2 pts
+B, 1 pt
+`,
+			true,
+		},
+		{[]string{"A 1, B 0", `!remove "A" "C" from 1`}, "", false},
+		{[]string{"A 1, B 0", `!remove "A" "B"`}, "", false},
+		{[]string{"A 1, B 0", `!remove "A" "B" from one`}, "", false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			var err error
+			for _, in := range tt.inputs {
+				if err = r.AddMatch(in); err != nil {
+					break
+				}
+			}
+
+			if !tt.ok {
+				if _, ok := err.(*DirectiveError); !ok {
+					t.Errorf("expected DirectiveError, got %T: %v", err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to add input: %v", err)
+			}
+			if got := r.Results(); got != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+
+	if got := RemoveDirective(2, `Team "A"`, `B\C`); got != `!remove "Team \"A\"" "B\\C" from 2` {
+		t.Errorf("wrong directive, got '%v'", got)
+	}
+}
+
+func TestGames_Edit_ParseFixture(t *testing.T) {
+	tests := []struct {
+		in    string
+		team1 string
+		team2 string
+		ok    bool
+	}{
+		{"Lions, Snakes", "Lions", "Snakes", true},
+		{`"Lions, the" | Snakes`, "Lions, the", "Snakes", true},
+		{"  FC  Awesome ,Grouches", "FC Awesome", "Grouches", true},
+		{"Lions", "", "", false},
+		{"Lions, , Snakes", "", "", false},
+		{"Lions, ", "", "", false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			t1, t2, err := ParseFixture(tt.in)
+			if tt.ok != (err == nil) {
+				t.Fatalf("expected okay: %v, got error: %v", tt.ok, err)
+			}
+			if t1 != tt.team1 || t2 != tt.team2 {
+				t.Errorf("wrong teams, expected '%v' & '%v', got '%v' & '%v'", tt.team1, tt.team2, t1, t2)
+			}
+		})
+	}
+}
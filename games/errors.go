@@ -63,3 +63,53 @@ type RecordGameError struct {
 func (rge RecordGameError) Error() string {
 	return fmt.Sprintf("error recording game for team '%v' on day %v, reason: %v", rge.team, rge.day, rge.err)
 }
+
+// =========================================================
+
+// DirectiveError is returned when an administrative directive
+// can't be parsed or applied
+type DirectiveError struct {
+	line string
+	err  error
+}
+
+// Error ...
+func (de DirectiveError) Error() string {
+	return fmt.Sprintf("unable to apply directive '%v': %v", de.line, de.err)
+}
+
+// Unwrap ...
+func (de DirectiveError) Unwrap() error {
+	return de.err
+}
+
+// =========================================================
+
+// MatchNotFoundError is returned when trying to void, amend
+// or remove a match that hasn't been recorded
+type MatchNotFoundError struct {
+	team1 string
+	team2 string
+
+	// day is zero when any day would do
+	day int
+}
+
+// Error ...
+func (mnf MatchNotFoundError) Error() string {
+	if mnf.day > 0 {
+		return fmt.Sprintf("no recorded match between '%v' and '%v' on day %v", mnf.team1, mnf.team2, mnf.day)
+	}
+	return fmt.Sprintf("no recorded match between '%v' and '%v'", mnf.team1, mnf.team2)
+}
+
+// WrittenDayError is returned when trying to change a match day that a
+// Stream has already written & forgotten, see Stream.Restart
+type WrittenDayError struct {
+	Day int
+}
+
+// Error ...
+func (wd WrittenDayError) Error() string {
+	return fmt.Sprintf("match day %v has already been written out and can't be changed", wd.Day)
+}
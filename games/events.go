@@ -0,0 +1,315 @@
+package games
+
+import (
+	"sort"
+	"sync"
+)
+
+// Event is something that happened to a ranking, one of the *Event
+// types below. Use a type switch to tell them apart.
+type Event interface {
+	// EventDay is the match day the event happened on
+	EventDay() int
+}
+
+// MatchRecordedEvent happens when a match is added to a match day,
+// including postponed & abandoned matches and forfeits
+type MatchRecordedEvent struct {
+	Day   int
+	Match Match
+}
+
+// DayStartedEvent happens when the first match of a match day is recorded
+type DayStartedEvent struct {
+	Day int
+}
+
+// DayClosedEvent happens when a match day is complete, which is when the
+// first match of the next day is found or Finish is called
+type DayClosedEvent struct {
+	Day       int
+	Standings []Standing
+}
+
+// LeaderChangedEvent happens when a different team goes top of the table.
+// Previous is empty for the first leader of the season. A team that draws
+// level on points with the leader doesn't take over until it has more
+// points, even if it's ahead in the table on name.
+type LeaderChangedEvent struct {
+	Day      int
+	Leader   string
+	Previous string
+	Points   int
+}
+
+// EnteredTopNEvent happens when a team moves into the top N of the table,
+// N is set with TrackTopN
+type EnteredTopNEvent struct {
+	Day      int
+	Team     string
+	Position int
+	N        int
+}
+
+// ClinchedEvent happens when a team can no longer finish the season below
+// Position, no matter what happens in the matches left to play. These need
+// the length of the season, set with SetSeasonDays.
+type ClinchedEvent struct {
+	Day      int
+	Team     string
+	Position int
+}
+
+// EventDay ...
+func (e MatchRecordedEvent) EventDay() int { return e.Day }
+
+// EventDay ...
+func (e DayStartedEvent) EventDay() int { return e.Day }
+
+// EventDay ...
+func (e DayClosedEvent) EventDay() int { return e.Day }
+
+// EventDay ...
+func (e LeaderChangedEvent) EventDay() int { return e.Day }
+
+// EventDay ...
+func (e EnteredTopNEvent) EventDay() int { return e.Day }
+
+// EventDay ...
+func (e ClinchedEvent) EventDay() int { return e.Day }
+
+// observers keeps track of who's subscribed to a ranking's events,
+// along with what's needed to notice when the table changes
+type observers struct {
+	// mu guards nextID & subs, so subscribers can come & go from
+	// other goroutines while matches are being added
+	mu     sync.Mutex
+	nextID int
+	subs   []subscriber
+
+	// topN & seasonDays are zero when the events that need
+	// them are turned off
+	topN       int
+	seasonDays int
+
+	// the state of the table after the last change
+	leader   string
+	inTopN   map[string]bool
+	clinched map[string]int
+
+	// closed is the last day a DayClosedEvent was sent for
+	closed int
+}
+
+// subscriber is a function subscribed to events
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// newObservers is the observers constructor
+func newObservers() *observers {
+	return &observers{inTopN: map[string]bool{}, clinched: map[string]int{}}
+}
+
+// Subscribe calls fn with every event from the ranking, in the order they
+// happen, until the returned function is called. Events are sent while
+// the match is being added, so fn shouldn't add matches itself.
+func (r *Ranking) Subscribe(fn func(Event)) func() {
+	r.obs.mu.Lock()
+	defer r.obs.mu.Unlock()
+	id := r.obs.nextID
+	r.obs.nextID++
+	r.obs.subs = append(r.obs.subs, subscriber{id: id, fn: fn})
+
+	return func() {
+		r.obs.mu.Lock()
+		defer r.obs.mu.Unlock()
+		for i, s := range r.obs.subs {
+			if s.id == id {
+				r.obs.subs = append(r.obs.subs[:i], r.obs.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Events returns a channel that gets every event from the ranking, and a
+// function that unsubscribes & closes the channel. Adding a match waits
+// until its events have been sent, so the channel has to be read from,
+// or given a big enough buffer, for adding matches to carry on.
+//
+// The function can be called from any goroutine, more than once. An event
+// still waiting to be sent when it's called is dropped.
+func (r *Ranking) Events(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	done := make(chan struct{})
+
+	// mu stops the channel being closed part way through a send
+	var mu sync.Mutex
+	var once sync.Once
+	unsubscribe := r.Subscribe(func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		select {
+		case <-done:
+		case ch <- e:
+		}
+	})
+
+	return ch, func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+			mu.Lock()
+			defer mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// TrackTopN turns on EnteredTopNEvent for the top n teams, zero turns it off
+func (r *Ranking) TrackTopN(n int) {
+	r.obs.topN = n
+}
+
+// SetSeasonDays sets how many match days there are in the season, which
+// turns on ClinchedEvent. Each team plays once a match day, so this is
+// how the number of matches each team has left is worked out.
+func (r *Ranking) SetSeasonDays(n int) {
+	r.obs.seasonDays = n
+}
+
+// Finish sends the DayClosedEvent for the last match day, for when there
+// are no more matches to add
+func (r *Ranking) Finish() {
+	r.dayClosed(r.currentMatch)
+}
+
+// subscribers returns a copy of the current subscribers, so they can be
+// called without holding the lock
+func (o *observers) subscribers() []subscriber {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]subscriber{}, o.subs...)
+}
+
+// emit sends the event to every subscriber
+func (r *Ranking) emit(e Event) {
+	for _, s := range r.obs.subscribers() {
+		s.fn(e)
+	}
+}
+
+// matchRecorded sends the events for a match that's just been added to
+// the match day, t1 is the first team in the match
+func (r *Ranking) matchRecorded(md *matchDay, t1 string) {
+	if len(r.obs.subscribers()) == 0 {
+		return
+	}
+	if len(md.Order) == 1 {
+		r.emit(DayStartedEvent{Day: md.Day})
+	}
+	r.emit(MatchRecordedEvent{Day: md.Day, Match: md.match(t1)})
+}
+
+// dayClosed sends the event for a match day that's complete, if it
+// hasn't been sent already
+func (r *Ranking) dayClosed(md *matchDay) {
+	if md == nil || len(md.Order) == 0 || md.Day <= r.obs.closed {
+		return
+	}
+	r.obs.closed = md.Day
+	if len(r.obs.subscribers()) > 0 {
+		r.emit(DayClosedEvent{Day: md.Day, Standings: md.model().Standings})
+	}
+}
+
+// standingsChanged sends the events for changes to the table, called
+// after anything that changes the teams' points
+func (r *Ranking) standingsChanged() {
+	if len(r.obs.subscribers()) == 0 {
+		return
+	}
+
+	day := r.currentDay
+	table := r.table()
+	if len(table) == 0 {
+		return
+	}
+
+	// a team level on points with the leader doesn't take over
+	l := table[0]
+	if p, ok := table.points(r.obs.leader); l.teamName != r.obs.leader && (!ok || l.rank > p) {
+		r.emit(LeaderChangedEvent{Day: day, Leader: l.teamName, Previous: r.obs.leader, Points: l.rank})
+		r.obs.leader = l.teamName
+	}
+
+	if n := r.obs.topN; n > 0 {
+		inTopN := map[string]bool{}
+		for i, s := range table {
+			if i >= n {
+				break
+			}
+			inTopN[s.teamName] = true
+			if !r.obs.inTopN[s.teamName] {
+				r.emit(EnteredTopNEvent{Day: day, Team: s.teamName, Position: i + 1, N: n})
+			}
+		}
+		r.obs.inTopN = inTopN
+	}
+
+	// every team has played by the end of the first day, before then
+	// there could be teams that haven't been seen yet
+	if r.obs.seasonDays > 0 && r.obs.closed >= StartMatchDay {
+		for _, s := range table {
+			p := r.worstPosition(s, table)
+			if p < len(table) && (r.obs.clinched[s.teamName] == 0 || p < r.obs.clinched[s.teamName]) {
+				r.obs.clinched[s.teamName] = p
+				r.emit(ClinchedEvent{Day: day, Team: s.teamName, Position: p})
+			}
+		}
+	}
+}
+
+// table returns every team's current points, in table order
+func (r *Ranking) table() standingList {
+	sl := standingList{}
+	for _, t := range r.Teams {
+		sl = append(sl, standing{teamName: t.Name, rank: t.currentRank()})
+	}
+	sort.Sort(sl)
+	return sl
+}
+
+// points returns the named team's points, and whether it's in the list
+func (sl standingList) points(name string) (int, bool) {
+	for _, s := range sl {
+		if s.teamName == name {
+			return s.rank, true
+		}
+	}
+	return 0, false
+}
+
+// worstPosition returns the lowest position the team could finish the
+// season in, if it doesn't earn any more points and every other team wins
+// all of its remaining matches
+func (r *Ranking) worstPosition(s standing, table standingList) int {
+	pos := 1
+	for _, o := range table {
+		if o.teamName == s.teamName {
+			continue
+		}
+
+		left := r.obs.seasonDays - r.Teams[o.teamName].lastDayPlayed
+		if left < 0 {
+			left = 0
+		}
+		max := o.rank + matchWon.points()*left
+		if max > s.rank || (max == s.rank && o.teamName < s.teamName) {
+			pos++
+		}
+	}
+	return pos
+}
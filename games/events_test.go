@@ -0,0 +1,206 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+// eventLine is a short description of an event, to keep the expected
+// output in the tests readable
+func eventLine(e Event) string {
+	switch ev := e.(type) {
+	case MatchRecordedEvent:
+		return fmt.Sprintf("%v recorded %v %v, %v %v", ev.Day, ev.Match.Team1, ev.Match.Score1, ev.Match.Team2, ev.Match.Score2)
+	case DayStartedEvent:
+		return fmt.Sprintf("%v started", ev.Day)
+	case DayClosedEvent:
+		return fmt.Sprintf("%v closed, %v teams", ev.Day, len(ev.Standings))
+	case LeaderChangedEvent:
+		return fmt.Sprintf("%v leader %v (was '%v') %v pts", ev.Day, ev.Leader, ev.Previous, ev.Points)
+	case EnteredTopNEvent:
+		return fmt.Sprintf("%v top %v %v at %v", ev.Day, ev.N, ev.Team, ev.Position)
+	case ClinchedEvent:
+		return fmt.Sprintf("%v clinched %v at %v", ev.Day, ev.Team, ev.Position)
+	}
+	return fmt.Sprintf("unknown event %T", e)
+}
+
+func TestGames_Events(t *testing.T) {
+	tests := []struct {
+		inputs     []string
+		topN       int
+		seasonDays int
+		expect     string
+	}{
+		{
+			[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 0"},
+			0, 0,
+			`1 started
+1 recorded A 1, B 0
+1 leader A (was '') 3 pts
+1 recorded C 1, D 1
+1 closed, 4 teams
+2 started
+2 recorded A 2, C 0
+2 recorded B 1, D 0
+2 closed, 4 teams
+`,
+		},
+		{
+			// B takes the lead, then D goes into the top 2 when
+			// A has points taken away
+			[]string{"A 1, B 1", "C 0, D 0", "B 2, C 0", "A 0, D 0", `!deduct A 2 "late"`},
+			2, 0,
+			`1 started
+1 recorded A 1, B 1
+1 leader A (was '') 1 pts
+1 top 2 A at 1
+1 top 2 B at 2
+1 recorded C 0, D 0
+1 closed, 4 teams
+2 started
+2 recorded B 2, C 0
+2 leader B (was 'A') 4 pts
+2 recorded A 0, D 0
+2 top 2 D at 2
+2 closed, 4 teams
+`,
+		},
+		{
+			// A can't be caught once D has played on day 2, B & C
+			// can't fall below third once D has no matches left
+			[]string{"A 3, B 0", "C 0, D 0", "A 1, C 0", "B 2, D 0", "A 1, D 0", "B 1, C 0"},
+			0, 3,
+			`1 started
+1 recorded A 3, B 0
+1 leader A (was '') 3 pts
+1 recorded C 0, D 0
+1 closed, 4 teams
+2 started
+2 recorded A 1, C 0
+2 clinched A at 2
+2 recorded B 2, D 0
+2 clinched A at 1
+2 closed, 4 teams
+3 started
+3 recorded A 1, D 0
+3 clinched B at 3
+3 clinched C at 3
+3 recorded B 1, C 0
+3 clinched B at 2
+3 closed, 4 teams
+`,
+		},
+		{
+			// A draws level with B on points, so stays second even
+			// though it's ahead on name, until B has points taken away
+			[]string{"B 1, C 0", "A 1, D 0", "A 0, B 0", "C 2, D 0", `!deduct B 1`},
+			0, 0,
+			`1 started
+1 recorded B 1, C 0
+1 leader B (was '') 3 pts
+1 recorded A 1, D 0
+1 closed, 4 teams
+2 started
+2 recorded A 0, B 0
+2 recorded C 2, D 0
+2 leader A (was 'B') 4 pts
+2 closed, 4 teams
+`,
+		},
+		{
+			[]string{"A, B P", `!forfeit C D "no show"`},
+			0, 0,
+			`1 started
+1 recorded A 0, B 0
+1 leader A (was '') 0 pts
+1 recorded C 3, D 0
+1 leader C (was 'A') 3 pts
+1 closed, 4 teams
+`,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			r.TrackTopN(tt.topN)
+			r.SetSeasonDays(tt.seasonDays)
+
+			got := strings.Builder{}
+			r.Subscribe(func(e Event) {
+				got.WriteString(eventLine(e) + "\n")
+			})
+
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+			r.Finish()
+			r.Finish()
+
+			if out := got.String(); out != tt.expect {
+				t.Errorf("wrong events\ndiff:\n%v", diff.LineDiff(tt.expect, out))
+			}
+		})
+	}
+}
+
+func TestGames_Events_Channel(t *testing.T) {
+	r := NewRanking()
+	ch, stop := r.Events(10)
+	calls := 0
+	unsubscribe := r.Subscribe(func(Event) { calls++ })
+
+	if err := r.AddMatch("A 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+	unsubscribe()
+	if err := r.AddMatch("C 1, D 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+	stop()
+
+	got := []string{}
+	for e := range ch {
+		got = append(got, eventLine(e))
+	}
+	expect := []string{"1 started", "1 recorded A 1, B 0", "1 leader A (was '') 3 pts", "1 recorded C 1, D 0"}
+	if strings.Join(got, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("wrong events from channel\ndiff:\n%v", diff.LineDiff(strings.Join(expect, "\n"), strings.Join(got, "\n")))
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 events before unsubscribing, got %v", calls)
+	}
+}
+
+// TestGames_Events_Stop checks the channel can be closed while another
+// goroutine is adding matches
+func TestGames_Events_Stop(t *testing.T) {
+	r := NewRanking()
+	ch, stop := r.Events(0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if err := r.AddMatch(fmt.Sprintf("A %v, B 0", i)); err != nil {
+				t.Errorf("unable to add match: %v", err)
+				return
+			}
+		}
+	}()
+
+	<-ch
+	stop()
+	stop()
+	<-done
+
+	for range ch {
+	}
+}
@@ -0,0 +1,72 @@
+package games
+
+// flowNetwork is a graph for working out maximum flows, with node 0 as
+// the source & node 1 as the sink
+type flowNetwork struct {
+	// edges are stored in pairs, each edge followed by its reverse
+	to    []int
+	cap   []int
+	edges [][]int
+}
+
+// the source & sink nodes of every flowNetwork
+const (
+	flowSource = 0
+	flowSink   = 1
+)
+
+// newFlowNetwork creates a network with the given number of nodes,
+// including the source & sink
+func newFlowNetwork(nodes int) *flowNetwork {
+	return &flowNetwork{edges: make([][]int, nodes)}
+}
+
+// add adds an edge from one node to another with the given capacity
+func (fn *flowNetwork) add(from, to, capacity int) {
+	fn.edges[from] = append(fn.edges[from], len(fn.to))
+	fn.to, fn.cap = append(fn.to, to), append(fn.cap, capacity)
+	fn.edges[to] = append(fn.edges[to], len(fn.to))
+	fn.to, fn.cap = append(fn.to, from), append(fn.cap, 0)
+}
+
+// maxFlow returns the most that can flow from the source to the sink,
+// using the shortest augmenting path each time
+func (fn *flowNetwork) maxFlow() int {
+	total := 0
+	for {
+		// via is the edge used to reach each node, -1 if it hasn't been
+		via := make([]int, len(fn.edges))
+		for i := range via {
+			via[i] = -1
+		}
+		via[flowSource] = len(fn.to)
+
+		queue := []int{flowSource}
+		for len(queue) > 0 && via[flowSink] < 0 {
+			n := queue[0]
+			queue = queue[1:]
+			for _, e := range fn.edges[n] {
+				if fn.cap[e] > 0 && via[fn.to[e]] < 0 {
+					via[fn.to[e]] = e
+					queue = append(queue, fn.to[e])
+				}
+			}
+		}
+		if via[flowSink] < 0 {
+			return total
+		}
+
+		// the reverse of edge e is e^1, which leads back along the path
+		amount := -1
+		for n := flowSink; n != flowSource; n = fn.to[via[n]^1] {
+			if c := fn.cap[via[n]]; amount < 0 || c < amount {
+				amount = c
+			}
+		}
+		for n := flowSink; n != flowSource; n = fn.to[via[n]^1] {
+			fn.cap[via[n]] -= amount
+			fn.cap[via[n]^1] += amount
+		}
+		total += amount
+	}
+}
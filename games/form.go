@@ -0,0 +1,103 @@
+package games
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultFormMatches is how many matches the form table looks back over
+// unless told otherwise
+const DefaultFormMatches = 5
+
+// FormStanding is a team's place in a form table
+type FormStanding struct {
+	Position int
+	Team     string
+
+	// Points are the points earned in the team's last few results,
+	// without any adjustments
+	Points int
+
+	// Form is the team's last few results, oldest first, with "W" for
+	// a win, "D" for a draw and "L" for a loss, like "WWDLW"
+	Form string
+}
+
+// Form returns the form table at the end of the given match day, or the
+// last match day if day is zero. Every team that's played by then is
+// ranked by the points earned in its last n results, then by name.
+// Postponed, abandoned and voided matches aren't results, so they're
+// skipped over, and forfeits count with their awarded score.
+//
+// It needs the results of earlier days, so it doesn't look back past
+// the days a Stream has already forgotten.
+func (r *Ranking) Form(day, n int) ([]FormStanding, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("the form table needs at least 1 match, got %v", n)
+	}
+	if day == 0 {
+		day = r.currentDay
+	}
+	if day < StartMatchDay || day > r.currentDay {
+		return nil, fmt.Errorf("there's no match day %v, the last one is %v", day, r.currentDay)
+	}
+	if day < r.forgotten {
+		return nil, fmt.Errorf("match day %v has already been forgotten", day)
+	}
+
+	out := []FormStanding{}
+	for _, t := range r.Teams {
+		played := false
+		results := []matchResult{}
+		for d := day; d >= StartMatchDay && len(results) < n; d-- {
+			opp, ok := t.Played[d]
+			if !ok {
+				continue
+			}
+			played = true
+			if md, ok := r.Days[d]; ok {
+				if _, ok := md.Statuses[t.Name]; ok {
+					continue
+				}
+			}
+			results = append(results, t.result(d, r.Teams[opp]))
+		}
+		if !played {
+			continue
+		}
+
+		fs := FormStanding{Team: t.Name}
+		var form strings.Builder
+		for i := len(results) - 1; i >= 0; i-- {
+			fs.Points += results[i].points()
+			form.WriteString(results[i].letter())
+		}
+		fs.Form = form.String()
+		out = append(out, fs)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Points == out[j].Points {
+			return out[i].Team < out[j].Team
+		}
+		return out[i].Points > out[j].Points
+	})
+	for i := range out {
+		out[i].Position = i + 1
+	}
+	return out, nil
+}
+
+// result works out whether this team won, drew or lost the match it
+// played against opp on the given day, from the scores each recorded
+func (t *team) result(day int, opp *team) matchResult {
+	scored, conceded := t.Scores[day], opp.Scores[day]
+	switch {
+	case scored > conceded:
+		return matchWon
+	case scored < conceded:
+		return matchLost
+	}
+	return matchTied
+}
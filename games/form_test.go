@@ -0,0 +1,70 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_Form(t *testing.T) {
+	inputs := []string{
+		"A 1, B 0", "C 2, D 2",
+		"A 0, C 1", "B 3, D 1",
+		"A, D P", `!forfeit "B" "C"`,
+		`!deduct "A" 3`, "A 2, B 2", "C 0, D 1",
+		"D 1, A 1", "B 4, C 0", "B, C VOID",
+	}
+
+	tests := []struct {
+		day    int
+		n      int
+		expect string
+	}{
+		{1, 5, "1 A 3 W\n2 C 1 D\n3 D 1 D\n4 B 0 L\n"},
+		{3, 5, "1 B 6 LWW\n2 C 4 DWL\n3 A 3 WL\n4 D 1 DL\n"},
+		{0, 5, "1 B 7 LWWD\n2 A 5 WLDD\n3 D 5 DLWD\n4 C 4 DWLL\n"},
+		{0, 2, "1 B 4 WD\n2 D 4 WD\n3 A 2 DD\n4 C 0 LL\n"},
+	}
+
+	r := NewRanking()
+	for _, in := range inputs {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			form, err := r.Form(tt.day, tt.n)
+			if err != nil {
+				t.Fatalf("unable to get form: %v", err)
+			}
+
+			got := ""
+			for _, fs := range form {
+				got += fmt.Sprintf("%v %v %v %v\n", fs.Position, fs.Team, fs.Points, fs.Form)
+			}
+			if got != tt.expect {
+				t.Errorf("wrong form table\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Form_Errors(t *testing.T) {
+	r := NewRanking()
+	if err := r.AddMatch("A 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+
+	for i, x := range [][2]int{{1, 0}, {2, 5}, {-1, 5}} {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if _, err := r.Form(tt[0], tt[1]); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
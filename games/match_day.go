@@ -2,6 +2,7 @@ package games
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 )
@@ -24,10 +25,43 @@ type matchDay struct {
 	// in here twice, both a value and a key (easier lookups)
 	Matchups map[string]string
 
+	// the first team in each matchup, in the order the
+	// matches were processed
+	Order []string
+
 	// each team and their points total at the end of the day
 	Standings standingList
+
+	// matches awarded by forfeit, the key is the team awarded the
+	// win and the value is the reason given
+	Forfeits map[string]string
+
+	// status of any fixture that wasn't played to a result, keyed
+	// by both teams ( same as Matchups )
+	Statuses map[string]matchStatus
+
+	// fixtures that were postponed or abandoned on an earlier day and
+	// played on this one, the value is the day they were originally on
+	Rescheduled map[string]int
+
+	// whether each team was at home, away, or on neutral ground, keyed
+	// by both teams ( same as Matchups )
+	Roles map[string]venueRole
+
+	// the ground each match on neutral ground was played at, keyed by
+	// both teams ( same as Matchups )
+	Venues map[string]string
 }
 
+// venueRole is where a team played a match in relation to its home ground
+type venueRole int
+
+const (
+	roleHome venueRole = iota
+	roleAway
+	roleNeutral
+)
+
 // newMatchDay is the matchDay constructor
 func newMatchDay(d int) matchDay {
 	if d <= 0 {
@@ -35,10 +69,15 @@ func newMatchDay(d int) matchDay {
 	}
 
 	return matchDay{
-		Day:       d,
-		Teams:     map[string]int{},
-		Matchups:  map[string]string{},
-		Standings: standingList{},
+		Day:         d,
+		Teams:       map[string]int{},
+		Matchups:    map[string]string{},
+		Standings:   standingList{},
+		Forfeits:    map[string]string{},
+		Statuses:    map[string]matchStatus{},
+		Rescheduled: map[string]int{},
+		Roles:       map[string]venueRole{},
+		Venues:      map[string]string{},
 	}
 }
 
@@ -58,8 +97,16 @@ func (m *matchDay) teamPlayed(tn string) bool {
 //
 // It determines if the match resulted in a tie or not, and tells
 // each team in the match to record the game and records the rank
-// of each team after the match
+// of each team after the match. The first team is recorded as the
+// home team.
 func (m *matchDay) processMatchResults(t1, t2 *teamResult) error {
+	return m.processMatch(t1, t2, statusPlayed, "")
+}
+
+// processMatch does the work for processMatchResults, fixtures with
+// any status other than statusPlayed are recorded without either team
+// earning points. If venue isn't empty the match was on neutral ground.
+func (m *matchDay) processMatch(t1, t2 *teamResult, status matchStatus, venue string) error {
 	mo := t1.team.Name
 	so := t1.score
 
@@ -79,12 +126,23 @@ func (m *matchDay) processMatchResults(t1, t2 *teamResult) error {
 
 	m.Matchups[mo] = mt
 	m.Matchups[mt] = mo
+	m.Order = append(m.Order, mo)
+
+	m.Roles[mo], m.Roles[mt] = roleHome, roleAway
+	if venue != "" {
+		m.Roles[mo], m.Roles[mt] = roleNeutral, roleNeutral
+		m.Venues[mo], m.Venues[mt] = venue, venue
+	}
 
 	// add results to current match
 	r1 := matchWon
 	r2 := matchLost
 
-	if t1.score < t2.score {
+	if status != statusPlayed {
+		r1, r2 = matchNoResult, matchNoResult
+		m.Statuses[mo] = status
+		m.Statuses[mt] = status
+	} else if t1.score < t2.score {
 		r1, r2 = r2, r1
 	} else if t1.score == t2.score {
 		r1, r2 = matchTied, matchTied
@@ -99,16 +157,99 @@ func (m *matchDay) processMatchResults(t1, t2 *teamResult) error {
 		return &RecordGameError{m.Day, t2.team.Name, err}
 	}
 
-	add := []standing{{teamName: mo, rank: rank1}, {teamName: mt, rank: rank2}}
+	add := []standing{
+		{teamName: mo, rank: rank1, adjustments: t1.team.adjustmentsOn(m.Day)},
+		{teamName: mt, rank: rank2, adjustments: t2.team.adjustmentsOn(m.Day)},
+	}
 	m.Standings = append(m.Standings, add...)
 
 	return nil
 }
 
+// processForfeit records a match that was awarded to the winner by
+// forfeit, using the scores in the given results
+func (m *matchDay) processForfeit(winner, loser *teamResult, reason string) error {
+	if err := m.processMatchResults(winner, loser); err != nil {
+		return err
+	}
+	m.Forfeits[winner.team.Name] = reason
+	return nil
+}
+
+// updateStanding replaces the points and adjustments for a team
+// that has already played in this match day
+func (m *matchDay) updateStanding(name string, rank int, adj []adjustment) {
+	for i, v := range m.Standings {
+		if v.teamName == name {
+			m.Standings[i].rank = rank
+			m.Standings[i].adjustments = adj
+		}
+	}
+}
+
+// removeMatch removes the match between the two teams, along
+// with their standings for this day
+func (m *matchDay) removeMatch(t1, t2 string) {
+	for _, n := range []string{t1, t2} {
+		delete(m.Teams, n)
+		delete(m.Matchups, n)
+		delete(m.Forfeits, n)
+		delete(m.Statuses, n)
+		delete(m.Rescheduled, n)
+		delete(m.Roles, n)
+		delete(m.Venues, n)
+	}
+
+	order := []string{}
+	for _, n := range m.Order {
+		if n != t1 && n != t2 {
+			order = append(order, n)
+		}
+	}
+	m.Order = order
+
+	standings := standingList{}
+	for _, s := range m.Standings {
+		if s.teamName != t1 && s.teamName != t2 {
+			standings = append(standings, s)
+		}
+	}
+	m.Standings = standings
+}
+
+// standingNote returns the annotation shown next to a team
+// in the results, if there is one
+func (m matchDay) standingNote(s standing) string {
+	notes := []string{}
+	for _, a := range s.adjustments {
+		notes = append(notes, a.String())
+	}
+
+	if reason, ok := m.Forfeits[s.teamName]; ok {
+		n := fmt.Sprintf("awarded %v-0 by forfeit", ForfeitScore)
+		if reason != "" {
+			n = fmt.Sprintf("%v: %v", n, reason)
+		}
+		notes = append(notes, n)
+	}
+
+	return strings.Join(notes, "; ")
+}
+
 // Results is the nicely formatted results of the match day,
 // showing the top three teams in point standings for this day
 func (m matchDay) Results() string {
-	out := fmt.Sprintf("Matchday %v\n", m.Day)
+	var out strings.Builder
+	m.writeResults(&out)
+	return out.String()
+}
+
+// writeResults writes the same thing as Results to w
+func (m matchDay) writeResults(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Matchday %v\n", m.Day); err != nil {
+		return err
+	}
+
 	l := len(m.Standings)
 	if l > 3 {
 		l = 3
@@ -118,13 +259,18 @@ func (m matchDay) Results() string {
 	for i := 0; i < l; i++ {
 		t := m.Standings[i]
 		s := "pt"
-		if t.rank > 1 || t.rank == 0 {
+		if t.rank != 1 && t.rank != -1 {
 			s = "pts"
 		}
-		out = fmt.Sprintf("%v%v, %v %v\n", out, t.teamName, t.rank, s)
+		note := m.standingNote(t)
+		if note != "" {
+			note = fmt.Sprintf(" (%v)", note)
+		}
+		if _, err := fmt.Fprintf(w, "%v, %v %v%v\n", t.teamName, t.rank, s, note); err != nil {
+			return err
+		}
 	}
-
-	return out
+	return nil
 }
 
 // String is for the Stringer interface, a more compact version
@@ -151,7 +297,22 @@ func (m matchDay) String() string {
 			ks := m.Teams[k]
 			vs := m.Teams[v]
 
-			matchups = append(matchups, fmt.Sprintf("%v vs %v (Score: %v-%v)", k, v, ks, vs))
+			mu := fmt.Sprintf("%v vs %v (Score: %v-%v)", k, v, ks, vs)
+			if st, ok := m.Statuses[k]; ok {
+				mu = fmt.Sprintf("%v [%v]", mu, st)
+			}
+			if v, ok := m.Venues[k]; ok {
+				mu = fmt.Sprintf("%v [at %v]", mu, v)
+			}
+			if d, ok := m.Rescheduled[k]; ok {
+				mu = fmt.Sprintf("%v [rescheduled from day %v]", mu, d)
+			}
+			if _, ok := m.Forfeits[k]; ok {
+				mu = fmt.Sprintf("%v [%v forfeit]", mu, v)
+			} else if _, ok := m.Forfeits[v]; ok {
+				mu = fmt.Sprintf("%v [%v forfeit]", mu, k)
+			}
+			matchups = append(matchups, mu)
 		}
 	}
 	out = fmt.Sprintf("%v\n\tMatchups: \t%v\n\tStandings:\t", out, strings.Join(matchups, ", "))
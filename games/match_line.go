@@ -0,0 +1,282 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MatchDelimiter is the alternative to commas for separating the parts of
+// a match line, "<team 1> | <score 1> | <team 2> | <score 2>". Since the
+// team names and scores are separate fields, names can contain anything
+// except the delimiter itself.
+const MatchDelimiter = '|'
+
+// matchLine holds the parts of a match line before the
+// teams have been looked up
+type matchLine struct {
+	team1, score1 string
+	team2, score2 string
+	status        matchStatus
+
+	// venue is the neutral ground the match was played at, empty if
+	// the first team was at home
+	venue string
+}
+
+// VenuePrefix starts the optional venue at the end of a match line, for
+// matches played on neutral ground rather than at the first team's home,
+// "<team 1> <score 1>, <team 2> <score 2> @ <venue>"
+const VenuePrefix = '@'
+
+// splitMatchLine splits up a match line in one of the formats:
+//
+//	<team 1> <score 1>, <team 2> <score 2> [status]
+//	"<team 1>" <score 1>, "<team 2>" <score 2> [status]
+//	<team 1> | <score 1> | <team 2> | <score 2> [| status]
+//
+// Any of them can end with "@ <venue>" for a match on neutral ground.
+// Team names in double quotes can contain commas, digits, the delimiter,
+// or VenuePrefix. Scores are left empty if the status allows them to be
+// left out and they're missing.
+func splitMatchLine(input string) (*matchLine, error) {
+	line, venue, err := splitVenue(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var ml *matchLine
+	if usesDelimiter(line) {
+		ml, err = splitDelimitedLine(line)
+	} else {
+		ml, err = splitCommaLine(line)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ml.venue = venue
+	return ml, nil
+}
+
+// splitVenue splits "@ <venue>" off the end of a match line, the venue
+// is empty if there isn't one. Only the last VenuePrefix outside of quotes
+// and after a space starts the venue, so a name like "A@Home" doesn't need
+// quotes but "A @ Home" does.
+func splitVenue(input string) (string, string, error) {
+	at := -1
+	inQuote, escaped, space := false, false, true
+	for i, c := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote && c == VenuePrefix && space:
+			at = i
+		}
+		space = !inQuote && unicode.IsSpace(c)
+	}
+	if at < 0 {
+		return input, "", nil
+	}
+
+	venue := strings.TrimSpace(input[at+1:])
+	if strings.HasPrefix(venue, `"`) {
+		fields, err := splitQuoted(venue)
+		if err != nil || len(fields) != 1 {
+			return "", "", &ParseLineError{input}
+		}
+		venue = fields[0]
+	}
+	venue = strings.Join(strings.Fields(venue), " ")
+	if venue == "" {
+		return "", "", &ParseLineError{input}
+	}
+	return input[:at], venue, nil
+}
+
+// usesDelimiter returns true if the match line uses MatchDelimiter between
+// its parts. A line with a comma outside quotes only does if it has the
+// three or four delimiters the format needs, so a name like A|B in a line
+// with commas doesn't need quotes.
+func usesDelimiter(line string) bool {
+	u := unquoted(line)
+	n := strings.Count(u, string(MatchDelimiter))
+	return n > 0 && (!strings.ContainsRune(u, ',') || n == 3 || n == 4)
+}
+
+// splitCommaLine splits up a match line that uses commas between the teams
+func splitCommaLine(input string) (*matchLine, error) {
+	parts, err := splitOutsideQuotes(input, ',')
+	if err != nil {
+		return nil, &ParseLineError{input}
+	}
+
+	// empty parts are dropped, same as strings.FieldsFunc
+	nonEmpty := []string{}
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	if len(nonEmpty) != 2 {
+		return nil, &ParseLineError{input}
+	}
+
+	ml := &matchLine{}
+	var last string
+	last, ml.status = splitStatus(nonEmpty[1])
+	optional := ml.status.scoreOptional()
+
+	if ml.team1, ml.score1, err = splitTeamScore(nonEmpty[0], optional); err != nil {
+		return nil, err
+	}
+	if ml.team2, ml.score2, err = splitTeamScore(last, optional); err != nil {
+		return nil, err
+	}
+	return ml, nil
+}
+
+// splitDelimitedLine splits up a match line that uses MatchDelimiter
+// instead of commas
+func splitDelimitedLine(input string) (*matchLine, error) {
+	fields, err := splitOutsideQuotes(input, MatchDelimiter)
+	if err != nil || len(fields) < 4 || len(fields) > 5 {
+		return nil, &ParseLineError{input}
+	}
+
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+
+	ml := &matchLine{status: statusPlayed}
+	if len(fields) == 5 {
+		if ml.status, err = matchStatusFromString(fields[4]); err != nil {
+			return nil, &ParseLineError{input}
+		}
+	}
+
+	if ml.team1, err = unquoteName(fields[0]); err != nil {
+		return nil, err
+	}
+	if ml.team2, err = unquoteName(fields[2]); err != nil {
+		return nil, err
+	}
+	ml.score1, ml.score2 = fields[1], fields[3]
+
+	if !ml.status.scoreOptional() {
+		for _, s := range []string{ml.score1, ml.score2} {
+			if s == "" {
+				return nil, &ParseTeamError{score: s, err: fmt.Errorf("missing score")}
+			}
+		}
+	}
+	return ml, nil
+}
+
+// splitTeamScore splits a section of a match line in the form "<team> <score>"
+// or "\"<team>\" <score>" into the team name and score. If optional is
+// true and there's no score, the whole input is used as the team name.
+func splitTeamScore(in string, optional bool) (string, string, error) {
+	in = strings.TrimSpace(in)
+	if in == "" {
+		return "", "", &ParseTeamError{empty: true}
+	}
+
+	if strings.HasPrefix(in, `"`) {
+		fields, err := splitQuoted(in)
+		if err != nil {
+			return "", "", &ParseTeamError{score: in, err: err}
+		}
+
+		switch {
+		case fields[0] == "":
+			return "", "", &ParseTeamError{empty: true}
+		case len(fields) == 2:
+			return fields[0], fields[1], nil
+		case len(fields) == 1 && optional:
+			return fields[0], "", nil
+		case len(fields) == 1:
+			return "", "", &ParseTeamError{score: "", err: fmt.Errorf("missing score")}
+		}
+		return "", "", &ParseTeamError{score: strings.Join(fields[1:], " "), err: fmt.Errorf("unexpected text after team name")}
+	}
+
+	bits := strings.Fields(in)
+	x := len(bits)
+	score := bits[x-1]
+	if _, err := strconv.Atoi(score); err != nil && optional {
+		return strings.Join(bits, " "), "", nil
+	}
+	return strings.Join(bits[0:x-1], " "), score, nil
+}
+
+// unquoteName returns the team name from a field that contains only
+// a team name, removing the quotes if it's quoted
+func unquoteName(in string) (string, error) {
+	if strings.HasPrefix(in, `"`) {
+		fields, err := splitQuoted(in)
+		if err != nil || len(fields) != 1 {
+			return "", &ParseTeamError{score: in, err: fmt.Errorf("expected a single quoted team name")}
+		}
+		in = fields[0]
+	}
+
+	in = strings.Join(strings.Fields(in), " ")
+	if in == "" {
+		return "", &ParseTeamError{empty: true}
+	}
+	return in, nil
+}
+
+// splitOutsideQuotes splits the input on sep, ignoring any sep
+// found between a pair of double quotes
+func splitOutsideQuotes(in string, sep rune) ([]string, error) {
+	out := []string{}
+	var cur strings.Builder
+	inQuote, escaped := false, false
+
+	for _, c := range in {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote && c == sep:
+			out = append(out, cur.String())
+			cur.Reset()
+			continue
+		}
+		cur.WriteRune(c)
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	return append(out, cur.String()), nil
+}
+
+// unquoted returns the input with anything between double
+// quotes removed
+func unquoted(in string) string {
+	var out strings.Builder
+	inQuote, escaped := false, false
+	for _, c := range in {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote:
+			out.WriteRune(c)
+		}
+	}
+	return out.String()
+}
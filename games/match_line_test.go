@@ -0,0 +1,83 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGames_MatchLine_SplitMatchLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		expect matchLine
+		ok     bool
+	}{
+		{"A 1, B 2", matchLine{"A", "1", "B", "2", statusPlayed, ""}, true},
+		{`"A, B" 1, "C 3" 2 ABD`, matchLine{"A, B", "1", "C 3", "2", statusAbandoned, ""}, true},
+		{`"A, B", "C 3" P`, matchLine{"A, B", "", "C 3", "", statusPostponed, ""}, true},
+		{"Team A, Team B P", matchLine{"Team A", "", "Team B", "", statusPostponed, ""}, true},
+		{"A,1 | 1 | B | 2", matchLine{"A,1", "1", "B", "2", statusPlayed, ""}, true},
+		{"A | | B | | VOID", matchLine{"A", "", "B", "", statusVoid, ""}, true},
+		{`"A | B" 1, C 2`, matchLine{"A | B", "1", "C", "2", statusPlayed, ""}, true},
+		{"A|B 1, C 2", matchLine{"A|B", "1", "C", "2", statusPlayed, ""}, true},
+		{"A|B 1, C|D 2 P", matchLine{"A|B", "1", "C|D", "2", statusPostponed, ""}, true},
+		{`"A" 1, "B 2`, matchLine{}, false},
+		{"A | 1 | B | 2 | P | X", matchLine{}, false},
+		{`"A" "B" 1, C 2`, matchLine{}, false},
+		{"A 1, B 2 @ Wembley  Stadium", matchLine{"A", "1", "B", "2", statusPlayed, "Wembley Stadium"}, true},
+		{"A, B P @ Wembley", matchLine{"A", "", "B", "", statusPostponed, "Wembley"}, true},
+		{`A | 1 | B | 2 @ "Stade de France, Paris"`, matchLine{"A", "1", "B", "2", statusPlayed, "Stade de France, Paris"}, true},
+		{`"A @ Home" 1, B@Away 2`, matchLine{"A @ Home", "1", "B@Away", "2", statusPlayed, ""}, true},
+		{"A 1, B 2 @", matchLine{}, false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got, err := splitMatchLine(tt.line)
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got: %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected okay, got error: %v", err)
+			}
+			if *got != tt.expect {
+				t.Errorf("wrong result\n\texpected: %+v\n\tgot:      %+v", tt.expect, *got)
+			}
+		})
+	}
+}
+
+func TestGames_MatchLine_RecordString(t *testing.T) {
+	one, two := 1, 2
+	tests := []struct {
+		record MatchRecord
+		expect string
+		parsed matchLine
+	}{
+		{MatchRecord{Team1: "A", Score1: &one, Team2: "B", Score2: &two}, "A | 1 | B | 2", matchLine{"A", "1", "B", "2", statusPlayed, ""}},
+		{MatchRecord{Team1: "A, B", Team2: "C 3", Status: "postponed"}, "A, B |  | C 3 |  | P", matchLine{"A, B", "", "C 3", "", statusPostponed, ""}},
+		{MatchRecord{Team1: `A|B`, Score1: &one, Team2: `"C"`, Score2: &one, Status: "ABD"}, `"A|B" | 1 | "\"C\"" | 1 | ABD`, matchLine{"A|B", "1", `"C"`, "1", statusAbandoned, ""}},
+		{MatchRecord{Team1: "A", Score1: &one, Team2: "B", Score2: &two, Venue: "Neutral @ Ground"}, `A | 1 | B | 2 @ "Neutral @ Ground"`, matchLine{"A", "1", "B", "2", statusPlayed, "Neutral @ Ground"}},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got := tt.record.String()
+			if got != tt.expect {
+				t.Errorf("wrong line, expected '%v' got '%v'", tt.expect, got)
+			}
+
+			ml, err := splitMatchLine(got)
+			if err != nil {
+				t.Fatalf("unable to parse line '%v': %v", got, err)
+			}
+			if *ml != tt.parsed {
+				t.Errorf("wrong result\n\texpected: %+v\n\tgot:      %+v", tt.parsed, *ml)
+			}
+		})
+	}
+}
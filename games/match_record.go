@@ -0,0 +1,137 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MatchRecord is a single match result from a structured source,
+// such as a CSV file or a JSON webhook, rather than a text line
+type MatchRecord struct {
+	Team1  string `json:"team1" yaml:"team1"`
+	Score1 *int   `json:"score1,omitempty" yaml:"score1,omitempty"`
+	Team2  string `json:"team2" yaml:"team2"`
+	Score2 *int   `json:"score2,omitempty" yaml:"score2,omitempty"`
+
+	// Status is empty for a played match, otherwise one of the status
+	// markers ( "P", "ABD", "VOID" ) or their names ( "postponed", etc )
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// Venue is the ground a match on neutral ground was played at, it's
+	// empty if Team1 was at home
+	Venue string `json:"venue,omitempty" yaml:"venue,omitempty"`
+}
+
+// matchLine converts the record into the same form as a parsed match line
+func (mr MatchRecord) matchLine() (*matchLine, error) {
+	status, err := matchStatusFromName(mr.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	ml := &matchLine{status: status, venue: strings.Join(strings.Fields(mr.Venue), " ")}
+	if ml.team1, err = unquoteName(mr.Team1); err != nil {
+		return nil, err
+	}
+	if ml.team2, err = unquoteName(mr.Team2); err != nil {
+		return nil, err
+	}
+
+	for _, x := range []struct {
+		in  *int
+		out *string
+	}{{mr.Score1, &ml.score1}, {mr.Score2, &ml.score2}} {
+		if x.in != nil {
+			*x.out = strconv.Itoa(*x.in)
+		} else if !status.scoreOptional() {
+			return nil, &ParseTeamError{score: "", err: fmt.Errorf("missing score")}
+		}
+	}
+	return ml, nil
+}
+
+// ParseMatchRecord parses a match line the same way AddMatch does,
+// returning it as a record instead of adding it to a ranking
+func ParseMatchRecord(in string) (MatchRecord, error) {
+	ml, err := splitMatchLine(in)
+	if err != nil {
+		return MatchRecord{}, err
+	}
+
+	mr := MatchRecord{Team1: ml.team1, Team2: ml.team2, Venue: ml.venue}
+	if ml.status != statusPlayed {
+		mr.Status = ml.status.marker
+	}
+
+	for _, x := range []struct {
+		in  string
+		out **int
+	}{{ml.score1, &mr.Score1}, {ml.score2, &mr.Score2}} {
+		if x.in == "" {
+			continue
+		}
+		s, err := strconv.Atoi(x.in)
+		if err != nil {
+			return MatchRecord{}, &ParseTeamError{score: x.in, err: err}
+		}
+		*x.out = &s
+	}
+	return mr, nil
+}
+
+// String returns the record as a match line that AddMatch accepts, using
+// MatchDelimiter between the fields so team names don't need quoting
+// unless they contain the delimiter
+func (mr MatchRecord) String() string {
+	fields := []string{quoteName(mr.Team1), scoreText(mr.Score1), quoteName(mr.Team2), scoreText(mr.Score2)}
+	if st, err := matchStatusFromName(mr.Status); err == nil && st != statusPlayed {
+		fields = append(fields, st.marker)
+	}
+	line := strings.Join(fields, fmt.Sprintf(" %c ", MatchDelimiter))
+	if mr.Venue != "" {
+		line = fmt.Sprintf("%v %c %v", line, VenuePrefix, quoteName(mr.Venue))
+	}
+	return line
+}
+
+// quoteName wraps the team or venue name in double quotes if it contains
+// anything that would stop it being read back in as-is
+func quoteName(n string) string {
+	if !strings.ContainsAny(n, string(MatchDelimiter)+string(VenuePrefix)+`"\`) {
+		return n
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return fmt.Sprintf(`"%v"`, r.Replace(n))
+}
+
+// scoreText is the score as text, or empty if there isn't one
+func scoreText(s *int) string {
+	if s == nil {
+		return ""
+	}
+	return strconv.Itoa(*s)
+}
+
+// matchStatusFromName is like matchStatusFromString, but also
+// accepts the name of the status
+func matchStatusFromName(s string) (matchStatus, error) {
+	s = strings.TrimSpace(s)
+	for _, st := range []matchStatus{statusPlayed, statusPostponed, statusAbandoned, statusVoid} {
+		if strings.EqualFold(s, st.String()) {
+			return st, nil
+		}
+	}
+	return matchStatusFromString(s)
+}
+
+// AddRecord adds a match from a structured source, creating a new match
+// day if either of the teams have already played today. It works the same
+// as AddMatch, except there's no text to parse.
+func (r *Ranking) AddRecord(mr MatchRecord) error {
+	ml, err := mr.matchLine()
+	if err != nil {
+		return err
+	}
+	return r._addMatch(func() error { return r.recordMatchLine(ml) }, 0)
+}
@@ -22,8 +22,37 @@ var (
 	matchWon     = matchResult{"won"}
 	matchLost    = matchResult{"lost"}
 	matchTied    = matchResult{"tied"}
+
+	// matchNoResult is for fixtures that didn't finish, the team
+	// uses up the day but doesn't earn any points
+	matchNoResult = matchResult{"no result"}
 )
 
+// points returns how many points a team earns for the result
+func (tmr matchResult) points() int {
+	switch tmr {
+	case matchWon:
+		return 3
+	case matchTied:
+		return 1
+	}
+	return 0
+}
+
+// letter returns the result as it's written in a form guide, "W", "D"
+// or "L", or empty if there wasn't a result
+func (tmr matchResult) letter() string {
+	switch tmr {
+	case matchWon:
+		return "W"
+	case matchTied:
+		return "D"
+	case matchLost:
+		return "L"
+	}
+	return ""
+}
+
 func matchResultFromString(s string) (matchResult, error) {
 	switch s {
 	case matchWon.outcome:
@@ -32,6 +61,8 @@ func matchResultFromString(s string) (matchResult, error) {
 		return matchLost, nil
 	case matchTied.outcome:
 		return matchTied, nil
+	case matchNoResult.outcome:
+		return matchNoResult, nil
 	}
 
 	return matchUnknown, fmt.Errorf("unknown result: %v", s)
@@ -0,0 +1,83 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+)
+
+// matchStatus is the state of a fixture, set using a marker
+// at the end of a match line
+type matchStatus struct {
+	marker string
+}
+
+// String ...
+func (ms matchStatus) String() string {
+	switch ms {
+	case statusPostponed:
+		return "postponed"
+	case statusAbandoned:
+		return "abandoned"
+	case statusVoid:
+		return "void"
+	}
+	return "played"
+}
+
+// scoreOptional returns true if match lines with this
+// status can leave out the scores
+func (ms matchStatus) scoreOptional() bool {
+	return ms == statusPostponed || ms == statusVoid
+}
+
+var (
+	statusPlayed    = matchStatus{""}
+	statusPostponed = matchStatus{"P"}
+	statusAbandoned = matchStatus{"ABD"}
+	statusVoid      = matchStatus{"VOID"}
+)
+
+// matchStatusFromString returns the status for the given marker,
+// markers aren't case sensitive
+func matchStatusFromString(s string) (matchStatus, error) {
+	switch strings.ToUpper(s) {
+	case statusPlayed.marker:
+		return statusPlayed, nil
+	case statusPostponed.marker:
+		return statusPostponed, nil
+	case statusAbandoned.marker:
+		return statusAbandoned, nil
+	case statusVoid.marker:
+		return statusVoid, nil
+	}
+
+	return statusPlayed, fmt.Errorf("unknown match status: %v", s)
+}
+
+// splitStatus removes a status marker from the end of the input,
+// returning the rest of the input and the status. If there's no
+// marker the status is statusPlayed.
+func splitStatus(in string) (string, matchStatus) {
+	in = strings.TrimSpace(in)
+	bits := strings.Fields(in)
+	if len(bits) == 0 {
+		return in, statusPlayed
+	}
+
+	last := bits[len(bits)-1]
+	st, err := matchStatusFromString(last)
+	if err != nil || st == statusPlayed {
+		return in, statusPlayed
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(in, last)), st
+}
+
+// fixtureKey returns a key for the fixture between two teams,
+// the same no matter which order the teams are given in
+func fixtureKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return fmt.Sprintf("%v\x00%v", a, b)
+}
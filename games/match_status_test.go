@@ -0,0 +1,121 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_MatchStatus_SplitStatus(t *testing.T) {
+	tests := []struct {
+		in     string
+		rest   string
+		status matchStatus
+	}{
+		{"B 2", "B 2", statusPlayed},
+		{"B 2 P", "B 2", statusPostponed},
+		{"  B  ABD ", "B", statusAbandoned},
+		{"B 2 void", "B 2", statusVoid},
+		{"Team P 2", "Team P 2", statusPlayed},
+		{"", "", statusPlayed},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			rest, st := splitStatus(tt.in)
+			if rest != tt.rest {
+				t.Errorf("wrong remaining input, expected '%v' got '%v'", tt.rest, rest)
+			}
+			if st != tt.status {
+				t.Errorf("wrong status, expected '%v' got '%v'", tt.status, st)
+			}
+		})
+	}
+}
+
+// statusCases are the inputs for TestGames_MatchStatus_Ranking, they're
+// also run through a Stream by TestGames_Stream_Cases
+func statusCases() []rankingCase {
+	return []rankingCase{
+		{
+			// postponed match uses up the day but gives no points,
+			// then gets played on day 2
+			[]string{"A 1, B 0", "C, D P", "A 2, B 2", "C 3, D 1"},
+			`Matchday 1
+A, 3 pts
+B, 0 pts
+C, 0 pts
+
+Matchday 2
+A, 4 pts
+C, 3 pts
+B, 1 pt
+`,
+			true,
+		},
+		{
+			// abandoned match keeps the score, but no points
+			[]string{"A 1, B 0", "C 2, D 0 ABD"},
+			`Matchday 1
+A, 3 pts
+B, 0 pts
+C, 0 pts
+`,
+			true,
+		},
+		{
+			// voiding a match from day 1 takes the points away from day 1 onward
+			[]string{"A 1, B 0", "C 2, D 2", "A 1, C 0", "B 1, D 0", "A, B VOID"},
+			`Matchday 1
+C, 1 pt
+D, 1 pt
+A, 0 pts
+
+Matchday 2
+A, 3 pts
+B, 3 pts
+C, 1 pt
+`,
+			true,
+		},
+		{[]string{"A 1, B 0", "A, C VOID"}, "", false},
+		{[]string{"A 1, B 0", "A, B VOID", "A, B VOID"}, "", false},
+		{[]string{"A, B ABD"}, "", false},
+	}
+}
+
+func TestGames_MatchStatus_Ranking(t *testing.T) {
+	for i, x := range statusCases() {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			var err error
+			for _, in := range tt.inputs {
+				if err = r.AddMatch(in); err != nil {
+					break
+				}
+			}
+
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to add input, got error: %v", err)
+			}
+
+			if i == 0 && r.Days[2].Rescheduled["C"] != 1 {
+				t.Errorf("expected C vs D on day 2 to be rescheduled from day 1")
+			}
+
+			got := r.Results()
+			if tt.expect != got {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
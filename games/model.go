@@ -0,0 +1,213 @@
+package games
+
+import (
+	"sort"
+)
+
+// Season is an exported view of everything in a Ranking, meant
+// for templates & other output formats to render
+type Season struct {
+	// Teams is the name of every team, sorted alphabetically
+	Teams []string `json:"teams"`
+
+	// Days is every match day, in order
+	Days []Day `json:"days"`
+}
+
+// Day is a single match day in a Season
+type Day struct {
+	// Number is the match day number, starting at StartMatchDay
+	Number int `json:"number"`
+
+	// Matches are the matches on this day, in the order they were added
+	Matches []Match `json:"matches"`
+
+	// Standings are all the teams that played on this day, ordered
+	// by points and then name
+	Standings []Standing `json:"standings"`
+}
+
+// Leaders returns the top n teams in the standings, none if n is
+// less than 1
+func (d Day) Leaders(n int) []Standing {
+	switch {
+	case n < 0:
+		n = 0
+	case n > len(d.Standings):
+		n = len(d.Standings)
+	}
+	return d.Standings[:n]
+}
+
+// Match is a single match in a Day
+type Match struct {
+	Team1  string `json:"team1"`
+	Score1 int    `json:"score1"`
+	Team2  string `json:"team2"`
+	Score2 int    `json:"score2"`
+
+	// Status is "played", "postponed", "abandoned" or "void"
+	Status string `json:"status"`
+
+	// Forfeit is the team that forfeited the match, if any
+	Forfeit string `json:"forfeit,omitempty"`
+
+	// RescheduledFrom is the day the match was originally on if
+	// it was postponed or abandoned, zero otherwise
+	RescheduledFrom int `json:"rescheduled_from,omitempty"`
+
+	// Venue is the ground the match was played at if it was on neutral
+	// ground, it's empty if Team1 was at home
+	Venue string `json:"venue,omitempty"`
+}
+
+// Standing is a team's position & points at the end of a Day
+type Standing struct {
+	Position int    `json:"position"`
+	Team     string `json:"team"`
+	Points   int    `json:"points"`
+
+	// Note explains any adjustments or forfeits, empty if there are none
+	Note string `json:"note,omitempty"`
+
+	// Adjustments are the administrative changes to the team's
+	// points that are in effect on this day
+	Adjustments []Adjustment `json:"adjustments,omitempty"`
+
+	// Forfeit is true if the team was awarded a match by forfeit on
+	// this day, ForfeitReason is the reason given, if any
+	Forfeit       bool   `json:"forfeit,omitempty"`
+	ForfeitReason string `json:"forfeit_reason,omitempty"`
+
+	// Race is whether the team has clinched a place at the top of the
+	// table or been eliminated from it, RaceUnknown unless it's been worked
+	// out, see Ranking.Races & Day.WithRaces
+	Race Race `json:"race,omitempty"`
+
+	// Schedule is how hard the team's fixtures are, nil unless it's been
+	// worked out, see Ranking.Schedules & Day.WithSchedules
+	Schedule *Schedule `json:"schedule,omitempty"`
+}
+
+// Adjustment is an administrative change to a team's points, deductions
+// have negative points
+type Adjustment struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Model returns the exported view of the ranking
+func (r Ranking) Model() Season {
+	s := Season{Teams: []string{}, Days: []Day{}}
+	for n := range r.Teams {
+		s.Teams = append(s.Teams, n)
+	}
+	sort.Strings(s.Teams)
+
+	for d := 0; d < r.currentDay; d++ {
+		if r.matches[d] != nil {
+			s.Days = append(s.Days, r.matches[d].model())
+		}
+	}
+	return s
+}
+
+// model returns the exported view of the match day
+func (m matchDay) model() Day {
+	d := Day{Number: m.Day, Matches: []Match{}, Standings: []Standing{}}
+
+	for _, t1 := range m.Order {
+		d.Matches = append(d.Matches, m.match(t1))
+	}
+
+	sl := append(standingList{}, m.Standings...)
+	sort.Sort(sl)
+	for i, v := range sl {
+		st := Standing{Position: i + 1, Team: v.teamName, Points: v.rank, Note: m.standingNote(v)}
+		for _, a := range v.adjustments {
+			st.Adjustments = append(st.Adjustments, Adjustment{Points: a.Points, Reason: a.Reason})
+		}
+		st.ForfeitReason, st.Forfeit = m.Forfeits[v.teamName]
+		d.Standings = append(d.Standings, st)
+	}
+	return d
+}
+
+// match returns the exported view of the match the team played in,
+// the team should be the first team in the match
+func (m matchDay) match(t1 string) Match {
+	t2 := m.Matchups[t1]
+	mt := Match{
+		Team1:           t1,
+		Score1:          m.Teams[t1],
+		Team2:           t2,
+		Score2:          m.Teams[t2],
+		Status:          statusPlayed.String(),
+		RescheduledFrom: m.Rescheduled[t1],
+		Venue:           m.Venues[t1],
+	}
+	if st, ok := m.Statuses[t1]; ok {
+		mt.Status = st.String()
+	}
+	if _, ok := m.Forfeits[t1]; ok {
+		mt.Forfeit = t2
+	} else if _, ok := m.Forfeits[t2]; ok {
+		mt.Forfeit = t1
+	}
+	return mt
+}
+
+// TeamMatch is a single match from the point of view of one team
+type TeamMatch struct {
+	Day          int
+	Opponent     string
+	GoalsFor     int
+	GoalsAgainst int
+
+	// Status is the same as Match.Status
+	Status string
+
+	// Result is "W", "D" or "L", or empty if the match didn't count
+	Result string
+
+	// Points & Position are the team's standing at the end of the day
+	Points   int
+	Position int
+}
+
+// TeamMatches returns every match the named team played, in order
+func (s Season) TeamMatches(name string) []TeamMatch {
+	out := []TeamMatch{}
+	for _, d := range s.Days {
+		for _, m := range d.Matches {
+			tm := TeamMatch{Day: d.Number, Status: m.Status}
+			switch name {
+			case m.Team1:
+				tm.Opponent, tm.GoalsFor, tm.GoalsAgainst = m.Team2, m.Score1, m.Score2
+			case m.Team2:
+				tm.Opponent, tm.GoalsFor, tm.GoalsAgainst = m.Team1, m.Score2, m.Score1
+			default:
+				continue
+			}
+
+			if m.Status == statusPlayed.String() {
+				switch {
+				case tm.GoalsFor > tm.GoalsAgainst:
+					tm.Result = "W"
+				case tm.GoalsFor < tm.GoalsAgainst:
+					tm.Result = "L"
+				default:
+					tm.Result = "D"
+				}
+			}
+
+			for _, st := range d.Standings {
+				if st.Team == name {
+					tm.Points, tm.Position = st.Points, st.Position
+				}
+			}
+			out = append(out, tm)
+		}
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package games
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGames_Model(t *testing.T) {
+	r := NewRanking()
+	inputs := []string{
+		"A 1, B 0",
+		"C, D P",
+		`!forfeit "A" "C" "no show"`,
+		"B 2, D 2",
+		`!deduct "B" 1 "late fee"`,
+	}
+	for _, in := range inputs {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	expect := Season{
+		Teams: []string{"A", "B", "C", "D"},
+		Days: []Day{
+			{
+				Number: 1,
+				Matches: []Match{
+					{Team1: "A", Score1: 1, Team2: "B", Score2: 0, Status: "played"},
+					{Team1: "C", Team2: "D", Status: "postponed"},
+				},
+				Standings: []Standing{
+					{Position: 1, Team: "A", Points: 3},
+					{Position: 2, Team: "B", Points: 0},
+					{Position: 3, Team: "C", Points: 0},
+					{Position: 4, Team: "D", Points: 0},
+				},
+			},
+			{
+				Number: 2,
+				Matches: []Match{
+					{Team1: "A", Score1: 3, Team2: "C", Score2: 0, Status: "played", Forfeit: "C"},
+					{Team1: "B", Score1: 2, Team2: "D", Score2: 2, Status: "played"},
+				},
+				Standings: []Standing{
+					{Position: 1, Team: "A", Points: 6, Note: "awarded 3-0 by forfeit: no show", Forfeit: true, ForfeitReason: "no show"},
+					{Position: 2, Team: "D", Points: 1},
+					{Position: 3, Team: "B", Points: 0, Note: "-1 pt: late fee", Adjustments: []Adjustment{{Points: -1, Reason: "late fee"}}},
+					{Position: 4, Team: "C", Points: 0},
+				},
+			},
+		},
+	}
+
+	got := r.Model()
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("wrong model\n\texpected: %+v\n\tgot:      %+v", expect, got)
+	}
+
+	leaders := got.Days[1].Leaders(3)
+	if len(leaders) != 3 || leaders[2].Team != "B" {
+		t.Errorf("wrong leaders: %+v", leaders)
+	}
+	if l := got.Days[1].Leaders(10); len(l) != 4 {
+		t.Errorf("expected leaders to be limited to the number of teams, got %v", len(l))
+	}
+	if l := got.Days[1].Leaders(-1); len(l) != 0 {
+		t.Errorf("expected no leaders for a negative count, got %v", len(l))
+	}
+}
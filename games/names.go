@@ -0,0 +1,215 @@
+package games
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// MaxSuggestDistance is the largest edit distance between two
+// team names where we'll still suggest one might be a typo of the other
+const MaxSuggestDistance = 2
+
+// Normalization controls which differences in team names are
+// ignored when deciding if two names are the same team
+type Normalization struct {
+	// FoldCase ignores upper & lower case, "Aptos FC" and "aptos fc"
+	FoldCase bool
+
+	// Whitespace ignores leading, trailing & repeated whitespace
+	Whitespace bool
+
+	// Punctuation ignores punctuation, "F.C." and "FC"
+	Punctuation bool
+}
+
+// key returns the normalized version of the name, used to
+// look up teams
+func (n Normalization) key(name string) string {
+	if n.Punctuation {
+		name = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) {
+				return -1
+			}
+			return r
+		}, name)
+	}
+	if n.Whitespace {
+		name = strings.Join(strings.Fields(name), " ")
+	}
+	if n.FoldCase {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+// NameWarning is created when a new team name is very similar to
+// a team name we've already seen, which is probably a typo
+type NameWarning struct {
+	Name       string
+	Suggestion string
+	Distance   int
+}
+
+// String ...
+func (nw NameWarning) String() string {
+	return fmt.Sprintf("new team '%v' is similar to existing team '%v', did you mean '%v'?", nw.Name, nw.Suggestion, nw.Suggestion)
+}
+
+// teamNames handles turning the names found in the input into
+// the canonical name for each team
+type teamNames struct {
+	norm Normalization
+
+	// aliases maps the normalized key of an alias to the canonical name
+	aliases map[string]string
+
+	// known maps the normalized key of a team to the name it was created with
+	known map[string]string
+
+	warnings []NameWarning
+}
+
+// newTeamNames is the teamNames constructor
+func newTeamNames() *teamNames {
+	return &teamNames{
+		aliases:  map[string]string{},
+		known:    map[string]string{},
+		warnings: []NameWarning{},
+	}
+}
+
+// canonical returns the name the team should be stored under, and
+// if it's a team that we haven't seen before
+func (tn *teamNames) canonical(name string) (string, bool) {
+	if c, ok := tn.aliases[tn.norm.key(name)]; ok {
+		name = c
+	}
+
+	k := tn.norm.key(name)
+	if n, ok := tn.known[k]; ok {
+		return n, false
+	}
+
+	if tn.norm.Whitespace {
+		name = strings.Join(strings.Fields(name), " ")
+	}
+	return name, true
+}
+
+// add records a new team name, creating a warning if it's
+// suspiciously close to a name we've already seen
+func (tn *teamNames) add(name string) {
+	k := tn.norm.key(name)
+
+	best, bestDist := "", -1
+	lk := strings.ToLower(k)
+	for ok, on := range tn.known {
+		d := editDistance(lk, strings.ToLower(ok))
+		if d > MaxSuggestDistance {
+			continue
+		}
+
+		// ignore short names, "A" and "B" aren't typos of each other
+		short := len([]rune(lk))
+		if l := len([]rune(ok)); l < short {
+			short = l
+		}
+		if d*4 > short {
+			continue
+		}
+
+		if bestDist < 0 || d < bestDist || (d == bestDist && on < best) {
+			best, bestDist = on, d
+		}
+	}
+
+	if bestDist >= 0 {
+		tn.warnings = append(tn.warnings, NameWarning{Name: name, Suggestion: best, Distance: bestDist})
+	}
+	tn.known[k] = name
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// min3 returns the smallest of three ints
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// SetNormalization sets which differences in team names are ignored. It
+// should be called before any matches are added.
+func (r *Ranking) SetNormalization(n Normalization) {
+	r.names.norm = n
+}
+
+// AddAlias makes any team name matching alias be recorded as the
+// canonical team name instead
+func (r *Ranking) AddAlias(alias, canonical string) {
+	r.names.aliases[r.names.norm.key(alias)] = canonical
+}
+
+// LoadAliases reads team name aliases, one per line, in the form:
+//
+//	<alias> = <canonical name>
+//
+// Blank lines and lines starting with '#' are skipped.
+func (r *Ranking) LoadAliases(in io.Reader) error {
+	s := bufio.NewScanner(in)
+	for ln := 1; s.Scan(); ln++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("line %v of aliases: expected '<alias> = <canonical name>', got '%v'", ln, line)
+		}
+
+		alias, canonical := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if alias == "" || canonical == "" {
+			return fmt.Errorf("line %v of aliases: alias and canonical name can't be blank", ln)
+		}
+		r.AddAlias(alias, canonical)
+	}
+
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("unable to read aliases: %w", err)
+	}
+	return nil
+}
+
+// Warnings returns any warnings about team names found so far
+func (r Ranking) Warnings() []NameWarning {
+	return r.names.warnings
+}
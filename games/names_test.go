@@ -0,0 +1,112 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGames_Names_EditDistance(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		expect int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"aptos fc", "aptos f.c.", 2},
+		{"santa cruz", "santa cruz", 0},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got := editDistance(tt.a, tt.b)
+			if got != tt.expect {
+				t.Errorf("wrong distance between '%v' and '%v', expected %v got %v", tt.a, tt.b, tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGames_Names_FindOrCreate(t *testing.T) {
+	tests := []struct {
+		norm     Normalization
+		aliases  map[string]string
+		names    []string
+		teams    []string
+		warnings int
+	}{
+		{Normalization{}, nil, []string{"Aptos FC", "aptos fc", "Aptos  F.C."}, []string{"Aptos FC", "aptos fc", "Aptos  F.C."}, 1},
+		{Normalization{FoldCase: true}, nil, []string{"Aptos FC", "aptos fc"}, []string{"Aptos FC"}, 0},
+		{Normalization{true, true, true}, nil, []string{"Aptos FC", "aptos fc", "Aptos  F.C."}, []string{"Aptos FC"}, 0},
+		{Normalization{}, map[string]string{"Aptos Football Club": "Aptos FC"}, []string{"Aptos FC", "Aptos Football Club"}, []string{"Aptos FC"}, 0},
+		{Normalization{}, nil, []string{"Santa Cruz Slugs", "Santa Cruz Slug"}, []string{"Santa Cruz Slugs", "Santa Cruz Slug"}, 1},
+		{Normalization{}, nil, []string{"A", "B", "C"}, []string{"A", "B", "C"}, 0},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			r.SetNormalization(tt.norm)
+			for k, v := range tt.aliases {
+				r.AddAlias(k, v)
+			}
+
+			for _, n := range tt.names {
+				r.findOrCreateTeam(n)
+			}
+
+			if len(r.Teams) != len(tt.teams) {
+				t.Errorf("wrong number of teams, expected %v got %v", len(tt.teams), len(r.Teams))
+			}
+			for _, n := range tt.teams {
+				if _, ok := r.Teams[n]; !ok {
+					t.Errorf("expected team '%v' to exist", n)
+				}
+			}
+
+			if len(r.Warnings()) != tt.warnings {
+				t.Errorf("wrong number of warnings, expected %v got %v: %v", tt.warnings, len(r.Warnings()), r.Warnings())
+			}
+		})
+	}
+}
+
+func TestGames_Names_LoadAliases(t *testing.T) {
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"# comment\n\nAptos Football Club = Aptos FC\nSJ = San Jose Earthquakes\n", true},
+		{"Aptos Football Club\n", false},
+		{" = Aptos FC\n", false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			err := r.LoadAliases(strings.NewReader(tt.in))
+			if tt.ok && err != nil {
+				t.Fatalf("expected okay, got error: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+				return
+			}
+
+			if err = r.AddMatch("SJ 1, Aptos Football Club 0"); err != nil {
+				t.Fatalf("unable to add match: %v", err)
+			}
+			for _, n := range []string{"San Jose Earthquakes", "Aptos FC"} {
+				if _, ok := r.Teams[n]; !ok {
+					t.Errorf("expected alias to be recorded as team '%v'", n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package games
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MaxPredictedGoals is the most goals a team is given a chance of scoring
+// in a prediction, anything more is too unlikely to matter
+const MaxPredictedGoals = 10
+
+// priorMatches is how many matches against an average team every team
+// is imagined to have played before the season, so one or two results
+// don't give a team an attack or defense of zero
+const priorMatches = 1
+
+// Poisson is a model of how many goals each team scores, fitted to the
+// results in a Ranking. The goals a team scores in a match are a Poisson
+// random variable with the mean
+//
+//	league average × attack × opponent's weakness × home advantage
+//
+// where home advantage is multiplied in for the home team, divided out
+// for the away team, and left out on neutral ground.
+type Poisson struct {
+	// Average is the goals a team with an average attack scores in a
+	// match against an average defense on neutral ground
+	Average float64
+
+	// HomeAdvantage is how much more the home team scores, and the
+	// away team scores less, than they would on neutral ground
+	HomeAdvantage float64
+
+	// Matches is how many results the model was fitted to
+	Matches int
+
+	attack   map[string]float64
+	weakness map[string]float64
+}
+
+// Strength is a team's fitted attack & defense, 1 is average for both.
+// An Attack of 1.5 scores half again as many goals as an average team,
+// a Defense of 1.5 concedes a third fewer.
+type Strength struct {
+	Team    string
+	Attack  float64
+	Defense float64
+}
+
+// Prediction is the chance of each result in a match between two teams
+type Prediction struct {
+	Team1   string
+	Team2   string
+	Neutral bool
+
+	// Expected1 & Expected2 are the goals each team is expected to score
+	Expected1 float64
+	Expected2 float64
+
+	// Win1, Draw & Win2 are the chances that the first team wins, the
+	// match is drawn, and the second team wins; they add up to 1
+	Win1 float64
+	Draw float64
+	Win2 float64
+
+	// Scores is every scoreline up to MaxPredictedGoals for each team,
+	// most likely first
+	Scores []Scoreline
+}
+
+// Scoreline is the chance of a match ending with a particular score
+type Scoreline struct {
+	Score1      int
+	Score2      int
+	Probability float64
+}
+
+// Likely returns the n most likely scorelines
+func (p Prediction) Likely(n int) []Scoreline {
+	switch {
+	case n < 0:
+		n = 0
+	case n > len(p.Scores):
+		n = len(p.Scores)
+	}
+	return p.Scores[:n]
+}
+
+// goalRecord is the goals one team scored in one match, the role is the
+// scoring team's
+type goalRecord struct {
+	day      int
+	team     string
+	opponent string
+	goals    int
+	role     venueRole
+}
+
+// goalRecords returns the goals scored by each team in each result up to
+// and including the given day. It's worked out from the scores recorded on
+// each team; postponed, abandoned & voided matches aren't results, and
+// forfeits are left out since the score is awarded rather than scored.
+func (r *Ranking) goalRecords(day int) []goalRecord {
+	out := []goalRecord{}
+	for _, t := range r.Teams {
+		for d, opp := range t.Played {
+			if d > day {
+				continue
+			}
+			md, ok := r.Days[d]
+			if !ok {
+				continue
+			}
+			if _, ok := md.Statuses[t.Name]; ok {
+				continue
+			}
+			if _, ok := md.Forfeits[t.Name]; ok {
+				continue
+			}
+			if _, ok := md.Forfeits[opp]; ok {
+				continue
+			}
+			out = append(out, goalRecord{day: d, team: t.Name, opponent: opp, goals: t.Scores[d], role: md.Roles[t.Name]})
+		}
+	}
+
+	// map order is random, so sort to make fitting repeatable
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].day != out[j].day {
+			return out[i].day < out[j].day
+		}
+		return out[i].team < out[j].team
+	})
+	return out
+}
+
+// FitPoisson fits the goal model to the results up to and including the
+// given match day, or every result if day is zero
+func (r *Ranking) FitPoisson(day int) (*Poisson, error) {
+	if day == 0 {
+		day = r.currentDay
+	}
+	recs := r.goalRecords(day)
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("there aren't any results to fit the model to")
+	}
+
+	p := &Poisson{HomeAdvantage: 1, Matches: len(recs) / 2, attack: map[string]float64{}, weakness: map[string]float64{}}
+	for n := range r.Teams {
+		p.attack[n], p.weakness[n] = 1, 1
+	}
+
+	goals := 0
+	for _, g := range recs {
+		goals += g.goals
+	}
+	p.Average = float64(goals) / float64(len(recs))
+	if goals == 0 {
+		return p, nil
+	}
+
+	// each parameter has a closed form answer when the others are held
+	// still, so keep solving for each in turn until they settle down
+	for i := 0; i < 500; i++ {
+		change := 0.0
+		track := func(old, x float64) float64 {
+			change = math.Max(change, math.Abs(old-x))
+			return x
+		}
+
+		scored, expected := map[string]float64{}, map[string]float64{}
+		for _, g := range recs {
+			scored[g.team] += float64(g.goals)
+			expected[g.team] += p.Average * p.weakness[g.opponent] * p.home(g.role)
+		}
+		prior := priorMatches * p.Average
+		for n := range p.attack {
+			p.attack[n] = track(p.attack[n], (scored[n]+prior)/(expected[n]+prior))
+		}
+
+		conceded, expected := map[string]float64{}, map[string]float64{}
+		for _, g := range recs {
+			conceded[g.opponent] += float64(g.goals)
+			expected[g.opponent] += p.Average * p.attack[g.team] * p.home(g.role)
+		}
+		for n := range p.weakness {
+			p.weakness[n] = track(p.weakness[n], (conceded[n]+prior)/(expected[n]+prior))
+		}
+
+		// solving for home advantage h gives X h² - (G - g) h - Y = 0, where
+		// G & g are the home & away goals, X & Y their expected goals without h
+		var home, away, x, y float64
+		for _, g := range recs {
+			e := p.Average * p.attack[g.team] * p.weakness[g.opponent]
+			switch g.role {
+			case roleHome:
+				home += float64(g.goals)
+				x += e
+			case roleAway:
+				away += float64(g.goals)
+				y += e
+			}
+		}
+		if x > 0 && y > 0 {
+			b := home - away
+			p.HomeAdvantage = track(p.HomeAdvantage, (b+math.Sqrt(b*b+4*x*y))/(2*x))
+		}
+
+		total := 0.0
+		for _, g := range recs {
+			total += p.attack[g.team] * p.weakness[g.opponent] * p.home(g.role)
+		}
+		p.Average = track(p.Average, float64(goals)/total)
+
+		if change < 1e-9 {
+			break
+		}
+	}
+	return p, nil
+}
+
+// home returns the home advantage for a team playing in the given role
+func (p *Poisson) home(role venueRole) float64 {
+	switch role {
+	case roleHome:
+		return p.HomeAdvantage
+	case roleAway:
+		return 1 / p.HomeAdvantage
+	}
+	return 1
+}
+
+// Strengths returns every team's attack & defense, sorted by name
+func (p *Poisson) Strengths() []Strength {
+	out := []Strength{}
+	for n, a := range p.attack {
+		out = append(out, Strength{Team: n, Attack: a, Defense: 1 / p.weakness[n]})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Team < out[j].Team })
+	return out
+}
+
+// Predict works out the chance of each result when team1 plays team2,
+// with team1 at home unless the match is on neutral ground. The names
+// have to be ones the ranking records teams under, see Ranking.TeamName.
+func (p *Poisson) Predict(team1, team2 string, neutral bool) (Prediction, error) {
+	for _, n := range []string{team1, team2} {
+		if _, ok := p.attack[n]; !ok {
+			return Prediction{}, fmt.Errorf("unknown team '%v'", n)
+		}
+	}
+	if team1 == team2 {
+		return Prediction{}, fmt.Errorf("'%v' can't play itself", team1)
+	}
+
+	r1, r2 := roleHome, roleAway
+	if neutral {
+		r1, r2 = roleNeutral, roleNeutral
+	}
+	pr := Prediction{
+		Team1:     team1,
+		Team2:     team2,
+		Neutral:   neutral,
+		Expected1: p.Average * p.attack[team1] * p.weakness[team2] * p.home(r1),
+		Expected2: p.Average * p.attack[team2] * p.weakness[team1] * p.home(r2),
+	}
+
+	g1, g2 := goalChances(pr.Expected1), goalChances(pr.Expected2)
+	total := 0.0
+	for s1, c1 := range g1 {
+		for s2, c2 := range g2 {
+			pr.Scores = append(pr.Scores, Scoreline{Score1: s1, Score2: s2, Probability: c1 * c2})
+			total += c1 * c2
+		}
+	}
+
+	// every scoreline is scaled up by the same amount to make up for the
+	// chances past MaxPredictedGoals, so everything adds up to 1
+	for i := range pr.Scores {
+		s := &pr.Scores[i]
+		s.Probability /= total
+		switch {
+		case s.Score1 > s.Score2:
+			pr.Win1 += s.Probability
+		case s.Score1 < s.Score2:
+			pr.Win2 += s.Probability
+		default:
+			pr.Draw += s.Probability
+		}
+	}
+
+	sort.SliceStable(pr.Scores, func(i, j int) bool { return pr.Scores[i].Probability > pr.Scores[j].Probability })
+	return pr, nil
+}
+
+// goalChances returns the chance of scoring each number of goals up to
+// MaxPredictedGoals when the expected number of goals is mean
+func goalChances(mean float64) []float64 {
+	out := make([]float64, MaxPredictedGoals+1)
+	c := math.Exp(-mean)
+	for k := range out {
+		out[k] = c
+		c *= mean / float64(k+1)
+	}
+	return out
+}
+
+// Calibration compares the predictions the model would have made before
+// each match day with the results on that day
+type Calibration struct {
+	// Matches is how many results were predicted, Correct is how many
+	// ended with the result the model thought was most likely
+	Matches int
+	Correct int
+
+	// Brier is the mean squared difference between the chance given to
+	// each result & whether it happened, added up over the three results.
+	// Giving every result the same chance scores 0.667, lower is better.
+	Brier float64
+
+	// LogLoss is the mean negative log of the chance given to the result
+	// that happened. Giving every result the same chance scores 1.099,
+	// lower is better.
+	LogLoss float64
+
+	// Buckets group the chances given to every result by how big they
+	// were, to check that things given a 30% chance happen 30% of the time
+	Buckets []CalibrationBucket
+}
+
+// CalibrationBucket is the predictions whose chance was in [From, To)
+type CalibrationBucket struct {
+	From float64
+	To   float64
+
+	// Predictions is how many chances fell in the bucket, Predicted is
+	// their mean, and Observed is how often those results happened
+	Predictions int
+	Predicted   float64
+	Observed    float64
+}
+
+// calibrationBuckets is how many buckets the chances are split into
+const calibrationBuckets = 10
+
+// Calibrate predicts every result from the second match day on, using a
+// model fitted to just the days before it, and compares the predictions
+// with what happened. Days before there's anything to fit to are skipped.
+func (r *Ranking) Calibrate() (Calibration, error) {
+	c := Calibration{}
+	happened := make([]int, calibrationBuckets)
+	predicted := make([]float64, calibrationBuckets)
+	counts := make([]int, calibrationBuckets)
+
+	for d := StartMatchDay + 1; d <= r.currentDay; d++ {
+		md, ok := r.Days[d]
+		if !ok || d <= r.forgotten {
+			continue
+		}
+		p, err := r.FitPoisson(d - 1)
+		if err != nil {
+			continue
+		}
+
+		for _, t1 := range md.Order {
+			t2 := md.Matchups[t1]
+			if _, ok := md.Statuses[t1]; ok {
+				continue
+			}
+			if _, ok := md.Forfeits[t1]; ok {
+				continue
+			}
+			if _, ok := md.Forfeits[t2]; ok {
+				continue
+			}
+
+			pr, err := p.Predict(t1, t2, md.Roles[t1] == roleNeutral)
+			if err != nil {
+				return Calibration{}, err
+			}
+
+			s1, s2 := md.Teams[t1], md.Teams[t2]
+			chances := []float64{pr.Win1, pr.Draw, pr.Win2}
+			actual := 1
+			if s1 > s2 {
+				actual = 0
+			} else if s1 < s2 {
+				actual = 2
+			}
+
+			best := 0
+			for i, ch := range chances {
+				if ch > chances[best] {
+					best = i
+				}
+
+				hit := 0.0
+				if i == actual {
+					hit = 1
+				}
+				c.Brier += (ch - hit) * (ch - hit)
+
+				b := int(ch * calibrationBuckets)
+				if b >= calibrationBuckets {
+					b = calibrationBuckets - 1
+				}
+				counts[b]++
+				predicted[b] += ch
+				happened[b] += int(hit)
+			}
+			if best == actual {
+				c.Correct++
+			}
+			c.LogLoss -= math.Log(math.Max(chances[actual], 1e-15))
+			c.Matches++
+		}
+	}
+
+	if c.Matches == 0 {
+		return c, fmt.Errorf("there aren't enough match days to check the predictions against")
+	}
+	c.Brier /= float64(c.Matches)
+	c.LogLoss /= float64(c.Matches)
+
+	for b := range counts {
+		cb := CalibrationBucket{From: float64(b) / calibrationBuckets, To: float64(b+1) / calibrationBuckets, Predictions: counts[b]}
+		if counts[b] > 0 {
+			cb.Predicted = predicted[b] / float64(counts[b])
+			cb.Observed = float64(happened[b]) / float64(counts[b])
+		}
+		c.Buckets = append(c.Buckets, cb)
+	}
+	return c, nil
+}
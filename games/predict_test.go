@@ -0,0 +1,173 @@
+package games
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// poissonSample draws a random number of goals with the given mean
+func poissonSample(rnd *rand.Rand, mean float64) int {
+	l, k, p := math.Exp(-mean), 0, 1.0
+	for {
+		p *= rnd.Float64()
+		if p <= l {
+			return k
+		}
+		k++
+	}
+}
+
+// simulatedSeason plays a round robin between the teams over and over,
+// with goals drawn from the model the predictions use
+func simulatedSeason(t *testing.T, days int, attack, defense []float64, average, home float64) *Ranking {
+	rnd := rand.New(rand.NewSource(3))
+	n := len(attack)
+	r := NewRanking()
+	for d := 0; d < days; d++ {
+		// the circle method, team 0 stays put & the rest rotate
+		order := []int{0}
+		for i := 0; i < n-1; i++ {
+			order = append(order, 1+(i+d)%(n-1))
+		}
+		for i := 0; i < n/2; i++ {
+			a, b := order[i], order[n-1-i]
+			if d%2 == 1 {
+				a, b = b, a
+			}
+			s1 := poissonSample(rnd, average*attack[a]/defense[b]*home)
+			s2 := poissonSample(rnd, average*attack[b]/defense[a]/home)
+			line := fmt.Sprintf("T%v %v, T%v %v", a, s1, b, s2)
+			if err := r.AddMatch(line); err != nil {
+				t.Fatalf("unable to add '%v': %v", line, err)
+			}
+		}
+	}
+	return r
+}
+
+func TestGames_FitPoisson_Recovers(t *testing.T) {
+	attack := []float64{1.6, 1.2, 1, 1, 0.8, 0.6}
+	defense := []float64{1.5, 1, 1.2, 0.9, 1, 0.7}
+	r := simulatedSeason(t, 300, attack, defense, 1.3, 1.2)
+
+	p, err := r.FitPoisson(0)
+	if err != nil {
+		t.Fatalf("unable to fit model: %v", err)
+	}
+	if p.Matches != 900 {
+		t.Errorf("wrong number of matches, expected 900 got %v", p.Matches)
+	}
+	if math.Abs(p.HomeAdvantage-1.2) > 0.08 {
+		t.Errorf("wrong home advantage, expected about 1.2 got %.3f", p.HomeAdvantage)
+	}
+
+	// only the ratios between teams are fixed, the overall level can
+	// move between the average & the strengths
+	st := p.Strengths()
+	for i, s := range st {
+		if s.Team != fmt.Sprintf("T%v", i) {
+			t.Fatalf("strengths out of order, got '%v' at %v", s.Team, i)
+		}
+		if got, expect := s.Attack/st[2].Attack, attack[i]/attack[2]; math.Abs(got-expect)/expect > 0.15 {
+			t.Errorf("wrong attack for %v, expected about %.2f of T2's got %.2f", s.Team, expect, got)
+		}
+		if got, expect := s.Defense/st[1].Defense, defense[i]/defense[1]; math.Abs(got-expect)/expect > 0.15 {
+			t.Errorf("wrong defense for %v, expected about %.2f of T1's got %.2f", s.Team, expect, got)
+		}
+	}
+}
+
+func TestGames_Predict(t *testing.T) {
+	r := NewRanking()
+	for _, in := range []string{"A 3, B 0", "C 1, D 1", "B 1, C 0", "D 0, A 2", "A, C P", `!forfeit "B" "D"`} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	p, err := r.FitPoisson(0)
+	if err != nil {
+		t.Fatalf("unable to fit model: %v", err)
+	}
+	if p.Matches != 4 {
+		t.Errorf("wrong number of matches, expected 4 got %v", p.Matches)
+	}
+
+	home, err := p.Predict("A", "B", false)
+	if err != nil {
+		t.Fatalf("unable to predict: %v", err)
+	}
+	if sum := home.Win1 + home.Draw + home.Win2; math.Abs(sum-1) > 1e-9 {
+		t.Errorf("chances should add up to 1, got %v", sum)
+	}
+	if home.Win1 <= home.Win2 || home.Expected1 <= home.Expected2 {
+		t.Errorf("expected A to be favourite, got %+v", home)
+	}
+	for i := 1; i < len(home.Scores); i++ {
+		if home.Scores[i].Probability > home.Scores[i-1].Probability {
+			t.Fatalf("scorelines aren't most likely first")
+		}
+	}
+	if l := home.Likely(3); len(l) != 3 || l[0] != home.Scores[0] {
+		t.Errorf("wrong likely scorelines, got %+v", l)
+	}
+	if l := home.Likely(-1); len(l) != 0 {
+		t.Errorf("expected no scorelines for a negative count, got %+v", l)
+	}
+	if l := home.Likely(len(home.Scores) + 1); len(l) != len(home.Scores) {
+		t.Errorf("expected every scoreline, got %v", len(l))
+	}
+
+	// on neutral ground it's the same match whichever team is first
+	n1, _ := p.Predict("C", "D", true)
+	n2, _ := p.Predict("D", "C", true)
+	if math.Abs(n1.Win1-n2.Win2) > 1e-9 || math.Abs(n1.Draw-n2.Draw) > 1e-9 || math.Abs(n1.Expected1-n2.Expected2) > 1e-9 {
+		t.Errorf("neutral predictions should mirror each other, got %+v & %+v", n1, n2)
+	}
+
+	for _, pair := range [][2]string{{"A", "E"}, {"A", "A"}} {
+		if _, err := p.Predict(pair[0], pair[1], false); err == nil {
+			t.Errorf("expected an error predicting '%v' v '%v'", pair[0], pair[1])
+		}
+	}
+
+	if _, err := NewRanking().FitPoisson(0); err == nil {
+		t.Errorf("expected an error fitting a model without results")
+	}
+}
+
+func TestGames_Calibrate(t *testing.T) {
+	attack := []float64{1.6, 1.2, 1, 1, 0.8, 0.6}
+	defense := []float64{1.5, 1, 1.2, 0.9, 1, 0.7}
+	r := simulatedSeason(t, 30, attack, defense, 1.3, 1.2)
+
+	c, err := r.Calibrate()
+	if err != nil {
+		t.Fatalf("unable to calibrate: %v", err)
+	}
+	if c.Matches != 87 {
+		t.Errorf("wrong number of matches, expected 87 got %v", c.Matches)
+	}
+
+	chances := 0
+	for _, b := range c.Buckets {
+		chances += b.Predictions
+		if b.Predictions > 0 && (b.Predicted < b.From || b.Predicted > b.To) {
+			t.Errorf("mean chance %v is outside its bucket %v-%v", b.Predicted, b.From, b.To)
+		}
+	}
+	if chances != 3*c.Matches {
+		t.Errorf("expected 3 chances for each match in the buckets, got %v", chances)
+	}
+
+	// a model that knows something should beat guessing
+	if c.Brier >= 2.0/3 || c.LogLoss >= math.Log(3) {
+		t.Errorf("predictions should beat guessing, got Brier %.3f & log loss %.3f", c.Brier, c.LogLoss)
+	}
+
+	if _, err := simulatedSeason(t, 1, attack, defense, 1.3, 1.2).Calibrate(); err == nil {
+		t.Errorf("expected an error with only one match day")
+	}
+}
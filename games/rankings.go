@@ -2,6 +2,7 @@ package games
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -29,6 +30,24 @@ type Ranking struct {
 	matches      []*matchDay
 	currentMatch *matchDay
 	currentDay   int
+
+	// forgotten is the first day that hasn't been freed by a Stream,
+	// everything before it has been written out & can't change
+	forgotten int
+
+	// forgottenResults is the last forgotten day each fixture has a
+	// result on, so voiding it can say which day it needs
+	forgottenResults map[string]int
+
+	// fixtures that were postponed or abandoned and haven't been
+	// played yet, the value is the day they were originally on
+	pending map[string]int
+
+	// names handles aliases & normalization of team names
+	names *teamNames
+
+	// obs are the subscribers to events from the ranking
+	obs *observers
 }
 
 // NewRanking is the constructor for Ranking structs
@@ -37,6 +56,11 @@ func NewRanking() *Ranking {
 		Teams:   map[string]*team{},
 		Days:    map[int]*matchDay{},
 		matches: []*matchDay{},
+		pending: map[string]int{},
+		names:   newTeamNames(),
+		obs:     newObservers(),
+
+		forgottenResults: map[string]int{},
 	}
 	r.newMatchDay(StartMatchDay)
 	return &r
@@ -82,56 +106,122 @@ func (r *Ranking) getCurrentMatchDay() *matchDay {
 }
 
 // AddMatch parses a match string, creating a new match day
-// if either of the teams in the string have already played today
+// if either of the teams in the string have already played today.
+//
+// Lines starting with DirectivePrefix are treated as administrative
+// directives, such as points deductions or forfeits.
 func (r *Ranking) AddMatch(in string) error {
-	return r._addMatch(in, 0)
+	if strings.HasPrefix(strings.TrimSpace(in), DirectivePrefix) {
+		return r._addMatch(func() error { return r.parseDirective(in) }, 0)
+	}
+	return r._addMatch(func() error { return r.parseMatchLine(in) }, 0)
 }
 
-// _addMatch does the actual work for AddMatch, with a guard
+// _addMatch does the actual work for AddMatch & AddRecord, with a guard
 // against infinite recursion, just in case.
-func (r *Ranking) _addMatch(in string, depth int) error {
+func (r *Ranking) _addMatch(add func() error, depth int) error {
 	if depth > 3 {
 		return fmt.Errorf("delved too deep")
 	}
 
-	err := r.parseMatchLine(in)
+	err := add()
 	if err != nil {
 		if _, ok := err.(*TeamPlayedError); ok {
+			r.dayClosed(r.currentMatch)
 			r.newMatchDay(r.currentDay + 1)
-			return r._addMatch(in, depth+1)
+			return r._addMatch(add, depth+1)
 		}
 		return err
 	}
+
+	r.standingsChanged()
 	return nil
 }
 
 // parseMatchLine parses a match line in the form "<team 1> <score 1>, <team 2> <score 2>",
 // and then tells the current match day to process the match results
+//
+// The line can end with a status marker: "P" for postponed, "ABD" for
+// abandoned, or "VOID" to cancel the result of an earlier match between
+// the two teams. Scores are optional for postponed and voided matches.
+//
+// Team names can be quoted, or the line can use MatchDelimiter instead of
+// commas; see splitMatchLine for details.
 func (r Ranking) parseMatchLine(input string) error {
-	parts := strings.FieldsFunc(input, func(r rune) bool { return r == ',' })
-	if len(parts) != 2 {
-		return &ParseLineError{input}
+	ml, err := splitMatchLine(input)
+	if err != nil {
+		return err
 	}
+	return r.recordMatchLine(ml)
+}
+
+// recordMatchLine looks up the teams in an already split match line,
+// then tells the current match day to process the match results
+func (r Ranking) recordMatchLine(ml *matchLine) error {
+	status := ml.status
+	optional := status.scoreOptional()
 
-	t1, err := r.parseTeamScore(parts[0])
+	t1, err := r.newTeamResult(ml.team1, ml.score1, optional)
 	if err != nil {
 		return err
 	}
 
-	t2, err := r.parseTeamScore(parts[1])
+	t2, err := r.newTeamResult(ml.team2, ml.score2, optional)
 	if err != nil {
 		return err
 	}
 
+	if status == statusVoid {
+		return r.voidMatch(t1.team, t2.team)
+	}
+
 	cm := r.getCurrentMatchDay()
-	err = cm.processMatchResults(t1, t2)
+	err = cm.processMatch(t1, t2, status, ml.venue)
 	if err != nil {
 		return err
 	}
 
+	key := fixtureKey(t1.team.Name, t2.team.Name)
+	if status == statusPlayed {
+		if d, ok := r.pending[key]; ok {
+			cm.Rescheduled[t1.team.Name] = d
+			cm.Rescheduled[t2.team.Name] = d
+			delete(r.pending, key)
+		}
+	} else if _, ok := r.pending[key]; !ok {
+		r.pending[key] = cm.Day
+	}
+
+	r.matchRecorded(cm, t1.team.Name)
 	return nil
 }
 
+// voidMatch cancels the most recent result between the two teams,
+// taking away any points either team earned from the match
+func (r Ranking) voidMatch(t1, t2 *team) error {
+	for d := r.currentDay; d >= StartMatchDay; d-- {
+		md, ok := r.Days[d]
+		if !ok || md.Matchups[t1.Name] != t2.Name {
+			continue
+		}
+		if _, ok := md.Statuses[t1.Name]; ok {
+			continue
+		}
+
+		for _, t := range []*team{t1, t2} {
+			_, changed := t.void(d)
+			r.updateStandings(t, changed)
+			md.Statuses[t.Name] = statusVoid
+		}
+		return nil
+	}
+
+	if d, ok := r.forgottenResults[fixtureKey(t1.Name, t2.Name)]; ok {
+		return &WrittenDayError{Day: d}
+	}
+	return &MatchNotFoundError{team1: t1.Name, team2: t2.Name}
+}
+
 // teamResult ...
 type teamResult struct {
 	team  *team
@@ -145,32 +235,54 @@ type teamResult struct {
 // This function creates a new team if the named
 // team isn't already known to this Ranking struct.
 func (r Ranking) parseTeamScore(in string) (*teamResult, error) {
-	in = strings.TrimSpace(in)
-	bits := strings.Fields(in)
-	x := len(bits)
-	if x <= 0 {
-		return nil, &ParseTeamError{empty: true}
+	name, score, err := splitTeamScore(in, false)
+	if err != nil {
+		return nil, err
 	}
+	return r.newTeamResult(name, score, false)
+}
 
-	name := strings.Join(bits[0:x-1], " ")
-	score, err := strconv.Atoi(string(bits[x-1]))
-	if err != nil {
-		return nil, &ParseTeamError{score: string(bits[x-1]), err: err}
+// newTeamResult looks up the named team and parses the score. If optional
+// is true an empty score is treated as zero.
+func (r Ranking) newTeamResult(name, score string, optional bool) (*teamResult, error) {
+	s := 0
+	if score != "" || !optional {
+		var err error
+		if s, err = strconv.Atoi(score); err != nil {
+			return nil, &ParseTeamError{score: score, err: err}
+		}
 	}
 
 	t := r.findOrCreateTeam(name)
-	return &teamResult{team: t, score: score}, nil
+	return &teamResult{team: t, score: s}, nil
 }
 
 // Results ...
 func (r Ranking) Results() string {
-	output := []string{}
+	var out strings.Builder
+	r.WriteResults(&out)
+	return out.String()
+}
 
+// WriteResults writes the same thing as Results to w, a match day at a
+// time. Days that a Stream has already written are left out.
+func (r Ranking) WriteResults(w io.Writer) error {
+	first := true
 	for d := 0; d < r.currentDay; d++ {
-		output = append(output, fmt.Sprintf("%v", r.matches[d].Results()))
+		if r.matches[d] == nil {
+			continue
+		}
+		if !first {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := r.matches[d].writeResults(w); err != nil {
+			return err
+		}
+		first = false
 	}
-
-	return strings.Join(output, "\n")
+	return nil
 }
 
 // String is for the Stringer interface, so that
@@ -31,6 +31,16 @@ func TestGames_Ranking_ParseMatchLine(t *testing.T) {
 		{"A, 2 B 3", "A", "B", -1, -1, false},
 		{"A 2 B 3", "A", "B", -1, -1, false},
 		{"", "", "", -1, -1, false},
+		{`"Brighton & Hove Albion, U23" 2, "1860 Munich 2" 1`, "Brighton & Hove Albion, U23", "1860 Munich 2", 2, 1, true},
+		{`"A \"B\"" 1, C 0`, `A "B"`, "C", 1, 0, true},
+		{`"A, B 1, C 0`, "", "", -1, -1, false},
+		{`"A" 1 2, C 0`, "", "", -1, -1, false},
+		{`"" 1, C 0`, "", "", -1, -1, false},
+		{"Brighton & Hove Albion, U23 | 2 | 1860 Munich 2 | 1", "Brighton & Hove Albion, U23", "1860 Munich 2", 2, 1, true},
+		{`  A  |3|  "B | C" | 3 `, "A", "B | C", 3, 3, true},
+		{"A | 3 | B", "", "", -1, -1, false},
+		{"A | | B | 1", "", "", -1, -1, false},
+		{"A | 1 | B | 1 | NOPE", "", "", -1, -1, false},
 	}
 
 	for i, x := range tests {
@@ -0,0 +1,90 @@
+package games
+
+import (
+	"fmt"
+)
+
+// Schedule is how hard a team's fixtures are, measured by the current
+// points of the opponents. Two teams level on points may have got there
+// against very different opposition.
+type Schedule struct {
+	// Played is how many matches the team has played & Past is the
+	// mean current points of those opponents
+	Played int     `json:"played"`
+	Past   float64 `json:"past"`
+
+	// Remaining is how many fixtures the team has left & Future is the
+	// mean current points of those opponents
+	Remaining int     `json:"remaining"`
+	Future    float64 `json:"future"`
+}
+
+// Schedules works out the strength of schedule for every team, from the
+// opponents recorded for each team and the fixtures left to play. Only
+// results count as played, postponed, abandoned & voided matches don't
+// since they're still to be played or no longer count.
+//
+// It needs every match the teams have played, so it can't be used once
+// a Stream has forgotten any match days.
+func (r *Ranking) Schedules(fixtures []Fixture) (map[string]Schedule, error) {
+	if r.forgotten > StartMatchDay {
+		return nil, fmt.Errorf("the strength of schedule needs every match day, but days before %v have been forgotten", r.forgotten)
+	}
+
+	past, future := map[string][]string{}, map[string][]string{}
+	for _, t := range r.Teams {
+		for d, opp := range t.Played {
+			if md, ok := r.Days[d]; ok {
+				if _, ok := md.Statuses[t.Name]; ok {
+					continue
+				}
+			}
+			past[t.Name] = append(past[t.Name], opp)
+		}
+	}
+
+	for _, f := range fixtures {
+		n1, n2, err := r.fixtureTeams(f)
+		if err != nil {
+			return nil, err
+		}
+		future[n1] = append(future[n1], n2)
+		future[n2] = append(future[n2], n1)
+	}
+
+	mean := func(opps []string) float64 {
+		if len(opps) == 0 {
+			return 0
+		}
+		total := 0
+		for _, o := range opps {
+			total += r.Teams[o].currentRank()
+		}
+		return float64(total) / float64(len(opps))
+	}
+
+	out := map[string]Schedule{}
+	for name := range r.Teams {
+		out[name] = Schedule{
+			Played:    len(past[name]),
+			Past:      mean(past[name]),
+			Remaining: len(future[name]),
+			Future:    mean(future[name]),
+		}
+	}
+	return out, nil
+}
+
+// WithSchedules returns a copy of the day with each team's strength of
+// schedule added to its standing
+func (d Day) WithSchedules(schedules map[string]Schedule) Day {
+	st := make([]Standing, len(d.Standings))
+	for i, s := range d.Standings {
+		if sc, ok := schedules[s.Team]; ok {
+			s.Schedule = &sc
+		}
+		st[i] = s
+	}
+	d.Standings = st
+	return d
+}
@@ -0,0 +1,103 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_Schedules(t *testing.T) {
+	tests := []struct {
+		inputs   []string
+		fixtures []Fixture
+		expect   string
+	}{
+		{
+			// the postponed match doesn't count for C or D
+			[]string{"A 1, B 0", "C, D P", "A 2, C 2", "B 3, D 1"},
+			nil,
+			"A 2 2.00 0 0.00\nB 2 2.00 0 0.00\nC 1 4.00 0 0.00\nD 1 3.00 0 0.00\n",
+		},
+		{
+			[]string{"A 1, B 0", "C, D P", "A 2, C 2", "B 3, D 1"},
+			[]Fixture{{"C", "D"}, {"A", "D"}},
+			"A 2 2.00 1 0.00\nB 2 2.00 0 0.00\nC 1 4.00 1 0.00\nD 1 3.00 2 2.50\n",
+		},
+		{
+			// adjustments count towards the opponents' current points
+			[]string{"A 1, B 1", `!deduct "B" 3 "late"`},
+			[]Fixture{{"B", "A"}},
+			"A 1 -2.00 1 -2.00\nB 1 1.00 1 1.00\n",
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			schedules, err := r.Schedules(tt.fixtures)
+			if err != nil {
+				t.Fatalf("unable to work out schedules: %v", err)
+			}
+
+			got := ""
+			for _, name := range r.Model().Teams {
+				s := schedules[name]
+				got += fmt.Sprintf("%v %v %.2f %v %.2f\n", name, s.Played, s.Past, s.Remaining, s.Future)
+			}
+			if got != tt.expect {
+				t.Errorf("wrong schedules\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Schedules_Errors(t *testing.T) {
+	r := NewRanking()
+	if err := r.AddMatch("A 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+	if _, err := r.Schedules([]Fixture{{"A", "C"}}); err == nil {
+		t.Errorf("expected an error for an unknown team")
+	}
+
+	st := NewStream(r, func(d Day) error { return nil })
+	if err := r.AddMatch("A 1, B 1"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+	if err := st.Flush(); err != nil {
+		t.Fatalf("unable to flush stream: %v", err)
+	}
+	if _, err := r.Schedules(nil); err == nil {
+		t.Errorf("expected an error once days have been forgotten")
+	}
+}
+
+func TestGames_Day_WithSchedules(t *testing.T) {
+	r := NewRanking()
+	if err := r.AddMatch("A 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+	schedules, err := r.Schedules(nil)
+	if err != nil {
+		t.Fatalf("unable to work out schedules: %v", err)
+	}
+
+	d := r.Model().Days[0]
+	got := d.WithSchedules(schedules)
+	if d.Standings[0].Schedule != nil {
+		t.Errorf("expected the original day to be left alone")
+	}
+	for _, s := range got.Standings {
+		if s.Schedule == nil || *s.Schedule != schedules[s.Team] {
+			t.Errorf("wrong schedule for %v, expected %+v got %+v", s.Team, schedules[s.Team], s.Schedule)
+		}
+	}
+}
@@ -9,8 +9,9 @@ package games
 // standing contains a team and their rank _after_ their matchup
 // in a day
 type standing struct {
-	teamName string
-	rank     int
+	teamName    string
+	rank        int
+	adjustments []adjustment
 }
 
 // standingList is a type that implements the methods for
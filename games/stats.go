@@ -0,0 +1,280 @@
+package games
+
+import (
+	"sort"
+)
+
+// Stats are goal statistics for a Season, for every team & the league
+// as a whole. Only played matches are counted, postponed, abandoned &
+// voided matches aren't, and neither are forfeits since the score is
+// awarded rather than scored. The first team in each match is the home
+// team, unless the match was on neutral ground.
+type Stats struct {
+	// Teams are the stats for each team, sorted by name
+	Teams []TeamStats
+
+	League LeagueStats
+}
+
+// Record is a team's results & goals over a set of matches
+type Record struct {
+	Played int
+	Won    int
+	Drawn  int
+	Lost   int
+
+	GoalsFor     int
+	GoalsAgainst int
+
+	// CleanSheets are matches without conceding, FailedToScore
+	// are matches without scoring
+	CleanSheets   int
+	FailedToScore int
+}
+
+// TeamStats are the stats for one team
+type TeamStats struct {
+	Team string
+
+	// Record is every match, Home & Away are split by where the team
+	// played, matches on neutral ground are in neither
+	Record
+	Home Record
+	Away Record
+
+	// BiggestWin & HighestScoring are nil if the team hasn't won or
+	// hasn't played
+	BiggestWin     *DayMatch
+	HighestScoring *DayMatch
+}
+
+// LeagueStats are the stats for every match in the season
+type LeagueStats struct {
+	Matches int
+	Goals   int
+
+	// HomeWins & AwayWins leave out matches on neutral ground, Draws
+	// includes them
+	HomeWins int
+	AwayWins int
+	Draws    int
+
+	// HomeGoals & AwayGoals leave out matches on neutral ground
+	HomeGoals int
+	AwayGoals int
+
+	// CleanSheets & FailedToScore count each team in each match, so a
+	// 0-0 draw is two clean sheets
+	CleanSheets   int
+	FailedToScore int
+
+	// BiggestWins & HighestScoring are the top matches by winning margin
+	// & by total goals, earliest first when they're level
+	BiggestWins    []DayMatch
+	HighestScoring []DayMatch
+}
+
+// DayMatch is a match along with the day it was played on
+type DayMatch struct {
+	Day int
+	Match
+}
+
+// Goals returns how many goals were scored in the match
+func (m Match) Goals() int {
+	return m.Score1 + m.Score2
+}
+
+// Margin returns how many goals the match was won by, zero for a draw
+func (m Match) Margin() int {
+	if m.Score1 > m.Score2 {
+		return m.Score1 - m.Score2
+	}
+	return m.Score2 - m.Score1
+}
+
+// GoalDifference returns goals for minus goals against
+func (r Record) GoalDifference() int {
+	return r.GoalsFor - r.GoalsAgainst
+}
+
+// GoalsPerMatch returns the average goals scored per match
+func (r Record) GoalsPerMatch() float64 {
+	return ratio(r.GoalsFor, r.Played)
+}
+
+// DrawPercent returns the percentage of matches that were drawn
+func (r Record) DrawPercent() float64 {
+	return 100 * ratio(r.Drawn, r.Played)
+}
+
+// GoalsPerMatch returns the average goals scored per match
+func (l LeagueStats) GoalsPerMatch() float64 {
+	return ratio(l.Goals, l.Matches)
+}
+
+// HomeWinPercent returns the percentage of matches the home team won
+func (l LeagueStats) HomeWinPercent() float64 {
+	return 100 * ratio(l.HomeWins, l.Matches)
+}
+
+// AwayWinPercent returns the percentage of matches the away team won
+func (l LeagueStats) AwayWinPercent() float64 {
+	return 100 * ratio(l.AwayWins, l.Matches)
+}
+
+// DrawPercent returns the percentage of matches that were drawn
+func (l LeagueStats) DrawPercent() float64 {
+	return 100 * ratio(l.Draws, l.Matches)
+}
+
+// ratio returns n/d, or zero if d is zero
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// Stats works out the goal statistics for the season, with the top
+// listed matches in the league's biggest wins & highest scoring lists
+func (s Season) Stats(listed int) Stats {
+	teams := map[string]*TeamStats{}
+	for _, t := range s.Teams {
+		teams[t] = &TeamStats{Team: t}
+	}
+
+	out := Stats{}
+	matches := []DayMatch{}
+	for _, d := range s.Days {
+		for _, m := range d.Matches {
+			if m.Status != statusPlayed.String() || m.Forfeit != "" {
+				continue
+			}
+			dm := DayMatch{Day: d.Number, Match: m}
+			matches = append(matches, dm)
+
+			l := &out.League
+			l.Matches++
+			l.Goals += m.Goals()
+			neutral := m.Venue != ""
+			if !neutral {
+				l.HomeGoals += m.Score1
+				l.AwayGoals += m.Score2
+			}
+			switch {
+			case m.Score1 == m.Score2:
+				l.Draws++
+			case neutral:
+			case m.Score1 > m.Score2:
+				l.HomeWins++
+			default:
+				l.AwayWins++
+			}
+
+			for _, side := range []struct {
+				team     string
+				scored   int
+				conceded int
+				home     bool
+			}{
+				{m.Team1, m.Score1, m.Score2, true},
+				{m.Team2, m.Score2, m.Score1, false},
+			} {
+				ts, ok := teams[side.team]
+				if !ok {
+					continue
+				}
+				ts.Record.add(side.scored, side.conceded)
+				switch {
+				case neutral:
+				case side.home:
+					ts.Home.add(side.scored, side.conceded)
+				default:
+					ts.Away.add(side.scored, side.conceded)
+				}
+
+				if side.conceded == 0 {
+					l.CleanSheets++
+				}
+				if side.scored == 0 {
+					l.FailedToScore++
+				}
+
+				if side.scored > side.conceded && (ts.BiggestWin == nil || biggerWin(dm, *ts.BiggestWin)) {
+					ts.BiggestWin = &DayMatch{Day: dm.Day, Match: dm.Match}
+				}
+				if ts.HighestScoring == nil || higherScoring(dm, *ts.HighestScoring) {
+					ts.HighestScoring = &DayMatch{Day: dm.Day, Match: dm.Match}
+				}
+			}
+		}
+	}
+
+	for _, t := range s.Teams {
+		out.Teams = append(out.Teams, *teams[t])
+	}
+
+	wins := []DayMatch{}
+	for _, dm := range matches {
+		if dm.Margin() > 0 {
+			wins = append(wins, dm)
+		}
+	}
+	sort.SliceStable(wins, func(i, j int) bool { return biggerWin(wins[i], wins[j]) })
+	sort.SliceStable(matches, func(i, j int) bool { return higherScoring(matches[i], matches[j]) })
+	out.League.BiggestWins = top(wins, listed)
+	out.League.HighestScoring = top(matches, listed)
+	return out
+}
+
+// add adds a match with the given score to the record
+func (r *Record) add(goalsFor, goalsAgainst int) {
+	r.Played++
+	r.GoalsFor += goalsFor
+	r.GoalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		r.Won++
+	case goalsFor < goalsAgainst:
+		r.Lost++
+	default:
+		r.Drawn++
+	}
+	if goalsAgainst == 0 {
+		r.CleanSheets++
+	}
+	if goalsFor == 0 {
+		r.FailedToScore++
+	}
+}
+
+// biggerWin returns true if a was won by more than b, or by the same
+// margin with more goals
+func biggerWin(a, b DayMatch) bool {
+	if a.Margin() != b.Margin() {
+		return a.Margin() > b.Margin()
+	}
+	return a.Goals() > b.Goals()
+}
+
+// higherScoring returns true if a had more goals than b, or the same
+// number of goals & a bigger margin
+func higherScoring(a, b DayMatch) bool {
+	if a.Goals() != b.Goals() {
+		return a.Goals() > b.Goals()
+	}
+	return a.Margin() > b.Margin()
+}
+
+// top returns the first n matches, or all of them if there are fewer,
+// a negative n returns none
+func top(ms []DayMatch, n int) []DayMatch {
+	if n < 0 {
+		n = 0
+	}
+	if n < len(ms) {
+		ms = ms[:n]
+	}
+	return ms
+}
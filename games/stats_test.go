@@ -0,0 +1,86 @@
+package games
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func TestGames_Stats(t *testing.T) {
+	r := NewRanking()
+	for _, in := range []string{
+		"A 3, B 0",
+		"C 2, D 2",
+		"B 1, C 4",
+		"D, A P",
+		"A 0, D 0",
+		`!forfeit B C`,
+		"A 5, B 5 ABD",
+	} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	st := r.Model().Stats(2)
+
+	tests := []struct {
+		got    interface{}
+		expect interface{}
+	}{
+		{st.League.Matches, 4},
+		{st.League.Goals, 12},
+		{[]int{st.League.HomeWins, st.League.AwayWins, st.League.Draws}, []int{1, 1, 2}},
+		{[]int{st.League.HomeGoals, st.League.AwayGoals}, []int{6, 6}},
+		{[]int{st.League.CleanSheets, st.League.FailedToScore}, []int{3, 3}},
+		{st.League.GoalsPerMatch(), 3.0},
+		{st.League.DrawPercent(), 50.0},
+		{st.Teams[0].Team, "A"},
+		{st.Teams[0].Record, Record{Played: 2, Won: 1, Drawn: 1, GoalsFor: 3, CleanSheets: 2, FailedToScore: 1}},
+		{st.Teams[0].Home, Record{Played: 2, Won: 1, Drawn: 1, GoalsFor: 3, CleanSheets: 2, FailedToScore: 1}},
+		{st.Teams[0].Away, Record{}},
+		{*st.Teams[0].BiggestWin, DayMatch{Day: 1, Match: Match{Team1: "A", Score1: 3, Team2: "B", Score2: 0, Status: "played"}}},
+		{st.Teams[1].GoalDifference(), -6},
+		{st.Teams[1].BiggestWin == nil, true},
+		{*st.Teams[1].HighestScoring, DayMatch{Day: 2, Match: Match{Team1: "B", Score1: 1, Team2: "C", Score2: 4, Status: "played"}}},
+		{st.Teams[3].DrawPercent(), 100.0},
+		{len(st.League.BiggestWins), 2},
+		// both won by 3, B & C had more goals
+		{st.League.BiggestWins[0].Team1, "B"},
+		{st.League.BiggestWins[1].Team1, "A"},
+		{st.League.HighestScoring[0].Team1, "B"},
+		{st.League.HighestScoring[1].Team1, "C"},
+		{len(r.Model().Stats(-1).League.BiggestWins), 0},
+		{len(r.Model().Stats(-1).League.HighestScoring), 0},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			expect, got := spew.Sdump(tt.expect), spew.Sdump(tt.got)
+			if got != expect {
+				t.Errorf("wrong stat\ndiff:\n%v", diff.LineDiff(expect, got))
+			}
+		})
+	}
+}
+
+func TestGames_Stats_Neutral(t *testing.T) {
+	r := NewRanking()
+	for _, in := range []string{"A 2, B 1 @ Wembley", "C 0, D 1"} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	st := r.Model().Stats(1)
+	if got, expect := []int{st.League.HomeWins, st.League.AwayWins, st.League.HomeGoals, st.League.AwayGoals}, []int{0, 1, 0, 1}; !reflect.DeepEqual(got, expect) {
+		t.Errorf("wrong home & away totals, expected %v got %v", expect, got)
+	}
+	if a := st.Teams[0]; a.Played != 1 || a.Home.Played != 0 || a.Away.Played != 0 {
+		t.Errorf("neutral match should only be in the overall record, got %+v", a)
+	}
+}
@@ -0,0 +1,116 @@
+package games
+
+// Stream hands each match day of a ranking to a function as soon as the
+// day is complete, then frees the memory the day used. A day is complete
+// once a later day has started, since that only happens when a team
+// plays again.
+//
+// Voiding a result, starting a deduction, or amending or removing a match
+// on a day that's been streamed fails with a WrittenDayError. To write the
+// change, call Restart with a new ranking, add the matches before the one
+// that failed to it again, add the one that failed, then call Resume.
+type Stream struct {
+	r    *Ranking
+	emit func(Day) error
+
+	// next is the next day to hand to emit, written is the last day
+	// that's been handed to emit
+	next    int
+	written int
+
+	// hold is the first day kept back since Restart, zero if nothing's
+	// being kept back, and rewrite is the first day that's written again
+	// once it's let go
+	hold    int
+	rewrite int
+}
+
+// NewStream creates a stream for the ranking. Flush should be called
+// after each match is added to the ranking, and Close once there are no
+// more matches to add.
+func NewStream(r *Ranking, emit func(Day) error) *Stream {
+	return &Stream{r: r, emit: emit, next: StartMatchDay}
+}
+
+// Flush hands every complete day that hasn't been streamed yet to emit
+func (s *Stream) Flush() error {
+	return s.flush(s.r.currentDay)
+}
+
+// Close hands every day that hasn't been streamed yet to emit, including
+// the current day, for when there are no more matches to add
+func (s *Stream) Close() error {
+	return s.flush(s.r.currentDay + 1)
+}
+
+// Restart starts the stream over on a new ranking, after a match failed
+// with a WrittenDayError for the given day. The days before it have
+// already been written & are the same, so they're skipped. The day & the
+// days after it are kept until Resume is called, so the match that failed
+// can change them.
+func (s *Stream) Restart(r *Ranking, day int) {
+	s.r, s.next = r, StartMatchDay
+	s.hold, s.rewrite = day, day
+}
+
+// Resume writes the days kept back since Restart again, now that the match
+// that changes them has been added, then streams the same as before
+func (s *Stream) Resume() error {
+	s.hold = 0
+	return s.Flush()
+}
+
+// flush streams the days before the given day
+func (s *Stream) flush(before int) error {
+	for ; s.next < before; s.next++ {
+		if s.hold > 0 && s.next >= s.hold {
+			return nil
+		}
+		md, ok := s.r.Days[s.next]
+		if !ok {
+			continue
+		}
+		if s.next > s.written || s.next >= s.rewrite {
+			if err := s.emit(md.model()); err != nil {
+				return err
+			}
+		}
+		if s.next > s.written {
+			s.written = s.next
+		}
+		s.r.forget(s.next + 1)
+	}
+	return nil
+}
+
+// forget frees the match days before the given day. Each team keeps the
+// points it had at the end of the day before, since later days build on
+// them, as well as the points from the last day it played.
+func (r *Ranking) forget(day int) {
+	for d := r.forgotten; d < day; d++ {
+		if md, ok := r.Days[d]; ok {
+			for _, t1 := range md.Order {
+				if _, ok := md.Statuses[t1]; !ok {
+					r.forgottenResults[fixtureKey(t1, md.Matchups[t1])] = d
+				}
+			}
+			r.matches[md.Day-StartMatchDay] = nil
+			delete(r.Days, d)
+		}
+	}
+
+	for _, t := range r.Teams {
+		for d := range t.Played {
+			if d < day {
+				delete(t.Played, d)
+				delete(t.Scores, d)
+			}
+		}
+		for d := range t.Standing {
+			if d < day-1 && d != t.lastDayPlayed {
+				delete(t.Standing, d)
+			}
+		}
+	}
+	r.forgotten = day
+}
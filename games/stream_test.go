@@ -0,0 +1,226 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/davecgh/go-spew/spew"
+)
+
+func TestGames_Stream(t *testing.T) {
+	tests := []struct {
+		inputs []string
+
+		// flushed is how many days have been streamed after each input
+		flushed []int
+	}{
+		{
+			[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 0", "A 0, D 0"},
+			[]int{0, 0, 1, 1, 2},
+		},
+		{
+			[]string{"A 1, B 0", `!deduct A 1 "late"`, `!forfeit C D`, "A, C P", "B 3, D 3", "A 1, C 1"},
+			[]int{0, 0, 0, 1, 1, 2},
+		},
+		{
+			[]string{},
+			[]int{},
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			full := NewRanking()
+			r := NewRanking()
+			got := []Day{}
+			s := NewStream(r, func(d Day) error {
+				got = append(got, d)
+				return nil
+			})
+
+			for j, in := range tt.inputs {
+				if err := full.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v' to streamed ranking: %v", in, err)
+				}
+				if err := s.Flush(); err != nil {
+					t.Fatalf("unable to flush: %v", err)
+				}
+				if len(got) != tt.flushed[j] {
+					t.Errorf("expected %v days streamed after '%v', got %v", tt.flushed[j], in, len(got))
+				}
+				if len(r.Days) > 1 {
+					t.Errorf("expected streamed days to be forgotten, ranking has %v days", len(r.Days))
+				}
+			}
+			if err := s.Close(); err != nil {
+				t.Fatalf("unable to close: %v", err)
+			}
+
+			expect := spew.Sdump(full.Model().Days)
+			if out := spew.Sdump(got); out != expect {
+				t.Errorf("streamed days don't match the full ranking\ndiff:\n%v", diff.LineDiff(expect, out))
+			}
+		})
+	}
+}
+
+func TestGames_Stream_Forgotten(t *testing.T) {
+	r := NewRanking()
+	s := NewStream(r, func(d Day) error { return nil })
+	for _, in := range []string{"A 1, B 0", "C 1, D 1", "A 2, C 0"} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+		if err := s.Flush(); err != nil {
+			t.Fatalf("unable to flush: %v", err)
+		}
+	}
+
+	// day 1 has been streamed, so it can't change any more
+	for _, in := range []string{`!deduct B 3 "late" from 1`, "A 1, B 0 VOID"} {
+		if err := r.AddMatch(in); err == nil {
+			t.Errorf("expected an error changing a streamed day with '%v'", in)
+		}
+	}
+	if err := r.AmendMatch(1, MatchRecord{Team1: "C", Score1: new(int), Team2: "D", Score2: new(int)}); err == nil {
+		t.Errorf("expected an error amending a streamed day")
+	}
+
+	// later days still build on the points from the streamed days
+	for _, in := range []string{"B 1, D 0", `!deduct B 1 "late" from 2`} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+	expect := `Matchday 2
+A, 6 pts
+B, 2 pts (-1 pt: late)
+C, 1 pt
+`
+	if got := r.Results(); got != expect {
+		t.Errorf("wrong results\ndiff:\n%v", diff.LineDiff(expect, got))
+	}
+}
+
+// streamLines adds the lines to a streamed ranking the same way parse does,
+// starting over whenever a line changes a day that's already been written.
+// It returns every day handed to emit, in order.
+func streamLines(lines []string) ([]Day, error) {
+	got := []Day{}
+	r := NewRanking()
+	s := NewStream(r, func(d Day) error {
+		got = append(got, d)
+		return nil
+	})
+
+	for i, in := range lines {
+		err := r.AddMatch(in)
+		var wd *WrittenDayError
+		if errors.As(err, &wd) {
+			r = NewRanking()
+			s.Restart(r, wd.Day)
+			for _, l := range lines[:i] {
+				if err := r.AddMatch(l); err != nil {
+					return nil, err
+				}
+				if err := s.Flush(); err != nil {
+					return nil, err
+				}
+			}
+			if err = r.AddMatch(in); err == nil {
+				err = s.Resume()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	return got, s.Close()
+}
+
+// TestGames_Stream_Cases runs the directive & match status cases through a
+// Stream, the last time each day is written it has to match the full ranking
+func TestGames_Stream_Cases(t *testing.T) {
+	cases := append(directiveCases(), statusCases()...)
+
+	for i, x := range cases {
+		tt := x
+		if !tt.ok {
+			continue
+		}
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			full := NewRanking()
+			for _, in := range tt.inputs {
+				if err := full.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			streamed, err := streamLines(tt.inputs)
+			if err != nil {
+				t.Fatalf("unable to stream: %v", err)
+			}
+			last := map[int]Day{}
+			for _, d := range streamed {
+				last[d.Number] = d
+			}
+			got := []Day{}
+			for n := StartMatchDay; n < StartMatchDay+len(last); n++ {
+				got = append(got, last[n])
+			}
+
+			expect := spew.Sdump(full.Model().Days)
+			if out := spew.Sdump(got); out != expect {
+				t.Errorf("streamed days don't match the full ranking\ndiff:\n%v", diff.LineDiff(expect, out))
+			}
+		})
+	}
+}
+
+func TestGames_Stream_Restart(t *testing.T) {
+	base := []string{"A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 0", "A 0, D 0", "B 2, C 2"}
+	tests := []struct {
+		line string
+
+		// written is the number of each day handed to emit, in order
+		written []int
+	}{
+		{"A 1, C 1", []int{1, 2, 3, 4}},
+		{"A, B VOID", []int{1, 2, 1, 2, 3}},
+		{"A, C VOID", []int{1, 2, 2, 3}},
+		{`!deduct "A" 3 from 1`, []int{1, 2, 1, 2, 3}},
+		{`!deduct "A" 3 from 3`, []int{1, 2, 3}},
+		{`!remove "C" "D" from 1`, []int{1, 2, 1, 2, 3}},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			streamed, err := streamLines(append(append([]string{}, base...), tt.line))
+			if err != nil {
+				t.Fatalf("unable to stream: %v", err)
+			}
+			got := []int{}
+			for _, d := range streamed {
+				got = append(got, d.Number)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.written) {
+				t.Errorf("expected days %v to be written, got %v", tt.written, got)
+			}
+		})
+	}
+
+	// voiding a match that was never played still fails
+	if _, err := streamLines(append(append([]string{}, base...), "A, E VOID")); err == nil {
+		t.Errorf("expected an error voiding a match that wasn't played")
+	}
+}
@@ -28,18 +28,33 @@ type team struct {
 	// Standing keeps track of what this teams point total was on each day
 	Standing map[int]int
 
+	// Adjustments are any administrative changes to this team's points,
+	// such as deductions
+	Adjustments []adjustment
+
 	// lastDayPlayed keeps track of the last day this team played on
 	lastDayPlayed int
 }
 
 // findOrCreateTeam looks up the team by name, and creates a new team
 // struct object if that team doesn't exist within the rankings
+//
+// Aliases and name normalization are applied before looking up the team,
+// so "Aptos FC" and "aptos fc" can be the same team.
 func (r Ranking) findOrCreateTeam(n string) *team {
 	t, ok := r.Teams[n]
 	if ok {
 		return t
 	}
 
+	n, isNew := r.names.canonical(n)
+	if t, ok = r.Teams[n]; ok {
+		return t
+	}
+	if isNew {
+		r.names.add(n)
+	}
+
 	t = &team{Name: n, Played: map[int]string{}, Scores: map[int]int{}, Standing: map[int]int{}}
 	r.Teams[n] = t
 	return t
@@ -64,11 +79,19 @@ func (t *team) recordGame(day int, name string, score int, res matchResult) (int
 		rank += 3
 	case matchTied:
 		rank++
-	case matchLost:
+	case matchLost, matchNoResult:
 	default:
 		return -1, fmt.Errorf("invalid match result '%v'", res)
 	}
 
+	// adjustments from earlier days are already part of the previous
+	// standing, only the ones starting today need to be added
+	for _, a := range t.Adjustments {
+		if a.Day == day {
+			rank += a.Points
+		}
+	}
+
 	t.Played[day] = name
 	t.Scores[day] = score
 	t.lastDayPlayed++
@@ -76,6 +99,85 @@ func (t *team) recordGame(day int, name string, score int, res matchResult) (int
 	return rank, nil
 }
 
+// adjust records an adjustment to this team's points. If the team
+// has already played on or after the day the adjustment starts, those
+// standings are updated and the days that changed are returned.
+func (t *team) adjust(a adjustment) []int {
+	t.Adjustments = append(t.Adjustments, a)
+
+	changed := []int{}
+	for d := a.Day; d <= t.lastDayPlayed; d++ {
+		t.Standing[d] += a.Points
+		changed = append(changed, d)
+	}
+	return changed
+}
+
+// void takes away the points this team earned from the match on the
+// given day, returning how many points were removed and which days had
+// their standings changed
+func (t *team) void(day int) (int, []int) {
+	earned := t.earned(day)
+	changed := []int{}
+	for d := day; d <= t.lastDayPlayed; d++ {
+		t.Standing[d] -= earned
+		changed = append(changed, d)
+	}
+	return earned, changed
+}
+
+// earned returns how many points this team earned from the match on
+// the given day, not counting any adjustments that start that day
+func (t *team) earned(day int) int {
+	earned := t.Standing[day] - t.Standing[day-1]
+	for _, a := range t.Adjustments {
+		if a.Day == day {
+			earned -= a.Points
+		}
+	}
+	return earned
+}
+
+// rescore replaces this team's score & result for the match on the given
+// day, returning which days had their standings changed
+func (t *team) rescore(day, score int, res matchResult) []int {
+	diff := res.points() - t.earned(day)
+	t.Scores[day] = score
+
+	changed := []int{}
+	for d := day; d <= t.lastDayPlayed; d++ {
+		t.Standing[d] += diff
+		changed = append(changed, d)
+	}
+	return changed
+}
+
+// unplay removes the match on the given day as if it had never been
+// played, returning which days had their standings changed. If it was
+// the last day this team played, the team can play on that day again.
+func (t *team) unplay(day int) []int {
+	_, changed := t.void(day)
+	delete(t.Played, day)
+	delete(t.Scores, day)
+
+	if day == t.lastDayPlayed {
+		delete(t.Standing, day)
+		t.lastDayPlayed--
+	}
+	return changed
+}
+
+// adjustmentsOn returns the adjustments in effect on the given day
+func (t *team) adjustmentsOn(day int) []adjustment {
+	out := []adjustment{}
+	for _, a := range t.Adjustments {
+		if a.Day <= day {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 // currentRank ...
 func (t *team) currentRank() int {
 	return t.Standing[t.lastDayPlayed]
@@ -0,0 +1,60 @@
+package games
+
+import (
+	"sort"
+)
+
+// HomeTable returns the standings counting only the matches each team
+// played at home, see venueTable
+func (s Season) HomeTable() []Standing {
+	return s.venueTable(true)
+}
+
+// AwayTable returns the standings counting only the matches each team
+// played away from home, see venueTable
+func (s Season) AwayTable() []Standing {
+	return s.venueTable(false)
+}
+
+// venueTable works out the standings from just the home or just the away
+// matches. Only played matches count, and not matches on neutral ground or
+// forfeits, and point adjustments are left out since they don't belong to
+// either. Every team is in the table, ordered by points and then name.
+func (s Season) venueTable(home bool) []Standing {
+	points := map[string]int{}
+	for _, t := range s.Teams {
+		points[t] = 0
+	}
+
+	for _, d := range s.Days {
+		for _, m := range d.Matches {
+			if m.Status != statusPlayed.String() || m.Forfeit != "" || m.Venue != "" {
+				continue
+			}
+
+			team, scored, conceded := m.Team1, m.Score1, m.Score2
+			if !home {
+				team, scored, conceded = m.Team2, m.Score2, m.Score1
+			}
+			result := matchLost
+			if scored > conceded {
+				result = matchWon
+			} else if scored == conceded {
+				result = matchTied
+			}
+			points[team] += result.points()
+		}
+	}
+
+	sl := standingList{}
+	for t, p := range points {
+		sl = append(sl, standing{teamName: t, rank: p})
+	}
+	sort.Sort(sl)
+
+	out := []Standing{}
+	for i, v := range sl {
+		out = append(out, Standing{Position: i + 1, Team: v.teamName, Points: v.rank})
+	}
+	return out
+}
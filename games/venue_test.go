@@ -0,0 +1,85 @@
+package games
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+)
+
+func TestGames_VenueTables(t *testing.T) {
+	tests := []struct {
+		inputs []string
+		home   string
+		away   string
+	}{
+		{
+			[]string{"A 1, B 0", "C 2, D 2", "B 3, C 1", "D 0, A 2"},
+			"1 A 3\n2 B 3\n3 C 1\n4 D 0\n",
+			"1 A 3\n2 D 1\n3 B 0\n4 C 0\n",
+		},
+		{
+			// neutral ground, postponed & forfeited matches and
+			// deductions don't count
+			[]string{"A 1, B 0 @ Wembley", "C, D P", `!forfeit "B" "C"`, `!deduct "A" 3`, "A 0, D 1"},
+			"1 A 0\n2 B 0\n3 C 0\n4 D 0\n",
+			"1 D 3\n2 A 0\n3 B 0\n4 C 0\n",
+		},
+		{
+			// a voided result is taken out
+			[]string{"A 1, B 0", "C 0, D 1", "A, B VOID", "C 1, A 1"},
+			"1 C 1\n2 A 0\n3 B 0\n4 D 0\n",
+			"1 D 3\n2 A 1\n3 B 0\n4 C 0\n",
+		},
+	}
+
+	table := func(st []Standing) string {
+		out := ""
+		for _, s := range st {
+			out += fmt.Sprintf("%v %v %v\n", s.Position, s.Team, s.Points)
+		}
+		return out
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			r := NewRanking()
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+
+			s := r.Model()
+			if got := table(s.HomeTable()); got != tt.home {
+				t.Errorf("wrong home table\ndiff:\n%v", diff.LineDiff(tt.home, got))
+			}
+			if got := table(s.AwayTable()); got != tt.away {
+				t.Errorf("wrong away table\ndiff:\n%v", diff.LineDiff(tt.away, got))
+			}
+		})
+	}
+}
+
+func TestGames_Venue_Roles(t *testing.T) {
+	r := NewRanking()
+	for _, in := range []string{"A 1, B 0 @ Hampden", "C 2, D 2"} {
+		if err := r.AddMatch(in); err != nil {
+			t.Fatalf("unable to add '%v': %v", in, err)
+		}
+	}
+
+	md := r.Days[StartMatchDay]
+	expect := map[string]venueRole{"A": roleNeutral, "B": roleNeutral, "C": roleHome, "D": roleAway}
+	for n, role := range expect {
+		if md.Roles[n] != role {
+			t.Errorf("wrong role for '%v', expected %v got %v", n, role, md.Roles[n])
+		}
+	}
+
+	ms := r.Model().Days[0].Matches
+	if ms[0].Venue != "Hampden" || ms[1].Venue != "" {
+		t.Errorf("wrong venues, expected 'Hampden' & '' got '%v' & '%v'", ms[0].Venue, ms[1].Venue)
+	}
+}
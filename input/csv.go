@@ -0,0 +1,157 @@
+package input
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// Columns maps the fields of a match to the names of the
+// CSV columns they're read from
+type Columns struct {
+	Team1  string
+	Score1 string
+	Team2  string
+	Score2 string
+
+	// Status is optional, if the column isn't in the file
+	// every match is treated as played
+	Status string
+
+	// Venue is optional, if the column isn't in the file or
+	// is empty the first team was at home
+	Venue string
+}
+
+// DefaultColumns are the column names used when none are given
+var DefaultColumns = Columns{
+	Team1:  "team1",
+	Score1: "score1",
+	Team2:  "team2",
+	Score2: "score2",
+	Status: "status",
+	Venue:  "venue",
+}
+
+// ParseColumns reads a column mapping like "team1=home,score1=home_goals",
+// any field not given uses the name from DefaultColumns
+func ParseColumns(in []string) (Columns, error) {
+	c := DefaultColumns
+	fields := map[string]*string{
+		"team1":  &c.Team1,
+		"score1": &c.Score1,
+		"team2":  &c.Team2,
+		"score2": &c.Score2,
+		"status": &c.Status,
+		"venue":  &c.Venue,
+	}
+
+	for _, m := range in {
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 {
+			return c, fmt.Errorf("expected column mapping in the form 'field=column', got '%v'", m)
+		}
+
+		f, ok := fields[strings.TrimSpace(parts[0])]
+		if !ok {
+			return c, fmt.Errorf("unknown field '%v', expected one of: team1, score1, team2, score2, status, venue", parts[0])
+		}
+		*f = strings.TrimSpace(parts[1])
+	}
+	return c, nil
+}
+
+// csvReader reads match data from CSV, using the header
+// row to find the columns
+type csvReader struct {
+	r *csv.Reader
+
+	// index of each column, status & venue are -1 if there's no
+	// status or venue column
+	team1, score1, team2, score2, status, venue int
+}
+
+// newCSVReader is the csvReader constructor, it reads the header row
+// right away so that missing columns are reported before any matches
+func newCSVReader(in io.Reader, cols Columns) (*csvReader, error) {
+	cr := &csvReader{r: csv.NewReader(in), status: -1, venue: -1}
+	cr.r.FieldsPerRecord = -1
+	cr.r.TrimLeadingSpace = true
+
+	header, err := cr.r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+
+	for _, x := range []struct {
+		name string
+		idx  *int
+	}{
+		{cols.Team1, &cr.team1},
+		{cols.Score1, &cr.score1},
+		{cols.Team2, &cr.team2},
+		{cols.Score2, &cr.score2},
+	} {
+		i, ok := index[x.name]
+		if !ok {
+			return nil, fmt.Errorf("CSV header is missing column '%v'", x.name)
+		}
+		*x.idx = i
+	}
+
+	if i, ok := index[cols.Status]; ok {
+		cr.status = i
+	}
+	if i, ok := index[cols.Venue]; ok {
+		cr.venue = i
+	}
+	return cr, nil
+}
+
+// Next returns the match from the next row
+func (cr *csvReader) Next() (Entry, error) {
+	row, err := cr.r.Read()
+	if err == io.EOF {
+		return Entry{}, err
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("error processing match data: %w", err)
+	}
+
+	ln, _ := cr.r.FieldPos(0)
+	e := Entry{Location: fmt.Sprintf("line %v", ln)}
+
+	cell := func(i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	mr := games.MatchRecord{Team1: cell(cr.team1), Team2: cell(cr.team2), Status: cell(cr.status), Venue: cell(cr.venue)}
+	for _, x := range []struct {
+		in  string
+		out **int
+	}{{cell(cr.score1), &mr.Score1}, {cell(cr.score2), &mr.Score2}} {
+		if x.in == "" {
+			continue
+		}
+		s, err := strconv.Atoi(x.in)
+		if err != nil {
+			return e, fmt.Errorf("error parsing %v of match data: unable to parse '%v' for score: %w", e.Location, x.in, err)
+		}
+		*x.out = &s
+	}
+
+	e.Record = &mr
+	return e, nil
+}
@@ -0,0 +1,145 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrTruncated is returned by Follower.Next when the file is now shorter
+// than what's already been read, or what's been read has been rewritten,
+// so it has to be read again from the start
+var ErrTruncated = errors.New("file was truncated or rewritten")
+
+// Follower reads the entries added to the end of a file as it grows. Only
+// formats with one entry per line, Text & JSON Lines, can be followed.
+type Follower struct {
+	f      *os.File
+	format Format
+
+	// offset is how much of the file has been read, always the end
+	// of a complete line
+	offset int64
+
+	// line is the number of the last line read
+	line int
+
+	// first & last are the first & last lines read, newline included,
+	// to check they're still there
+	first, last []byte
+}
+
+// CanFollow returns true if files in the format can be followed
+func CanFollow(f Format) bool {
+	return f == Text || f == JSONLines
+}
+
+// NewFollower creates a follower that starts at the beginning of the file
+func NewFollower(f *os.File, format Format) (*Follower, error) {
+	if !CanFollow(format) {
+		return nil, fmt.Errorf("unable to follow %v files, only %v and %v", format, Text, JSONLines)
+	}
+	return &Follower{f: f, format: format}, nil
+}
+
+// Next returns the entries from the complete lines added since the last
+// call. A line without a newline yet is left until the next call, since
+// it may still be being written. Lines that can't be read are skipped,
+// and the error from the first one is returned with the other entries.
+func (fl *Follower) Next() ([]Entry, error) {
+	info, err := fl.f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("unable to check match data: %w", err)
+	}
+	if info.Size() < fl.offset {
+		return nil, ErrTruncated
+	}
+	if changed, err := fl.rewritten(); err != nil || changed {
+		if err == nil {
+			err = ErrTruncated
+		}
+		return nil, err
+	}
+
+	buf := make([]byte, info.Size()-fl.offset)
+	n, err := fl.f.ReadAt(buf, fl.offset)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read match data: %w", err)
+	}
+
+	end := bytes.LastIndexByte(buf[:n], '\n')
+	if end < 0 {
+		return []Entry{}, nil
+	}
+	chunk := bytes.NewReader(buf[:end+1])
+	fl.offset += int64(end + 1)
+	fl.remember(buf[:end+1])
+
+	var rd Reader
+	var line *int
+	if fl.format == JSONLines {
+		jr := newJSONLinesReader(chunk)
+		rd, line = jr, &jr.ln
+	} else {
+		tr := newTextReader(chunk)
+		rd, line = tr, &tr.ln
+	}
+	*line = fl.line
+
+	out := []Entry{}
+	var first error
+	for {
+		before := *line
+		e, err := rd.Next()
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			out = append(out, e)
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		if *line == before {
+			// not a problem with a single line, so there's no
+			// point trying the rest
+			break
+		}
+	}
+
+	fl.line = *line
+	return out, first
+}
+
+// remember keeps the first & last lines of a chunk that's been read
+func (fl *Follower) remember(chunk []byte) {
+	if fl.first == nil {
+		fl.first = append([]byte{}, chunk[:bytes.IndexByte(chunk, '\n')+1]...)
+	}
+	start := bytes.LastIndexByte(chunk[:len(chunk)-1], '\n') + 1
+	fl.last = append([]byte{}, chunk[start:]...)
+}
+
+// rewritten returns true if the first or last line read isn't in the file
+// any more, as happens when it's truncated & written again. A rewrite
+// that only changes the lines in between isn't noticed.
+func (fl *Follower) rewritten() (bool, error) {
+	check := func(want []byte, at int64) (bool, error) {
+		got := make([]byte, len(want))
+		if _, err := fl.f.ReadAt(got, at); err != nil && err != io.EOF {
+			return false, fmt.Errorf("unable to read match data: %w", err)
+		}
+		return !bytes.Equal(got, want), nil
+	}
+
+	if fl.first == nil {
+		return false, nil
+	}
+	if changed, err := check(fl.first, 0); err != nil || changed {
+		return changed, err
+	}
+	return check(fl.last, fl.offset-int64(len(fl.last)))
+}
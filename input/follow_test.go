@@ -0,0 +1,131 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInput_Follower(t *testing.T) {
+	tests := []struct {
+		format Format
+		writes []string
+
+		// expect is the locations read after each write, or "error"
+		// if that read should return an error
+		expect [][]string
+	}{
+		{
+			Text,
+			[]string{"A 1, B 0\nC 1, D 1\n", "A 2, C", " 0\n", "\n", "B 1, D 0"},
+			[][]string{{"line 1", "line 2"}, {}, {"line 3"}, {"line 4"}, {}},
+		},
+		{
+			JSONLines,
+			[]string{`{"team1":"A","score1":1,"team2":"B","score2":0}` + "\n\n", "nope\n" + `{"team1":"C","score1":1,"team2":"D","score2":1}` + "\n"},
+			[][]string{{"line 1"}, {"error", "line 4"}},
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "matches")
+			w, err := os.Create(path)
+			if err != nil {
+				t.Fatalf("unable to create file: %v", err)
+			}
+			defer w.Close()
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("unable to open file: %v", err)
+			}
+			defer f.Close()
+
+			fl, err := NewFollower(f, tt.format)
+			if err != nil {
+				t.Fatalf("unable to create follower: %v", err)
+			}
+
+			for j, wr := range tt.writes {
+				if _, err := w.WriteString(wr); err != nil {
+					t.Fatalf("unable to write: %v", err)
+				}
+
+				entries, err := fl.Next()
+				got := []string{}
+				if err != nil {
+					got = append(got, "error")
+				}
+				for _, e := range entries {
+					got = append(got, e.Location)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(tt.expect[j]) {
+					t.Errorf("wrong entries after write %v, expected %v got %v", j, tt.expect[j], got)
+				}
+			}
+		})
+	}
+}
+
+func TestInput_Follower_Truncated(t *testing.T) {
+	tests := []struct {
+		rewrite string
+		expect  error
+	}{
+		// shorter
+		{"A 1, B 0\n", ErrTruncated},
+		// the same size, corrected
+		{"A 1, B 2\nC 1, D 1\n", ErrTruncated},
+		{"A 1, B 0\nC 1, D 3\n", ErrTruncated},
+		// longer, corrected & written part way
+		{"A 1, B 2\nC 1, D 1\nA 2", ErrTruncated},
+		// only added to
+		{"A 1, B 0\nC 1, D 1\nA 2, C 0\n", nil},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "matches.txt")
+			if err := os.WriteFile(path, []byte("A 1, B 0\nC 1, D 1\n"), 0644); err != nil {
+				t.Fatalf("unable to write file: %v", err)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("unable to open file: %v", err)
+			}
+			defer f.Close()
+
+			fl, err := NewFollower(f, Text)
+			if err != nil {
+				t.Fatalf("unable to create follower: %v", err)
+			}
+			if entries, err := fl.Next(); err != nil || len(entries) != 2 {
+				t.Fatalf("expected 2 entries and no error, got %v and %v", len(entries), err)
+			}
+
+			if err := os.WriteFile(path, []byte(tt.rewrite), 0644); err != nil {
+				t.Fatalf("unable to write file: %v", err)
+			}
+			if _, err := fl.Next(); err != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestInput_Follower_Format(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "testdata", "sample-input.txt"))
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := NewFollower(f, YAML); err == nil {
+		t.Errorf("expected an error following a YAML file")
+	}
+}
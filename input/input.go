@@ -0,0 +1,94 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// Format is a format that match data can be read from
+type Format string
+
+const (
+	// Text is the original "<team 1> <score 1>, <team 2> <score 2>" format
+	Text Format = "text"
+	// CSV is comma separated values, with a header row
+	CSV Format = "csv"
+	// JSONLines is one JSON object per line
+	JSONLines Format = "jsonl"
+	// YAML is a YAML list of matches
+	YAML Format = "yaml"
+)
+
+// Formats is every supported format
+var Formats = []Format{Text, CSV, JSONLines, YAML}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown input format '%v', expected one of: %v", s, Formats)
+}
+
+// DetectFormat guesses the format of a file from the extension,
+// anything unrecognized is treated as Text
+func DetectFormat(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV
+	case ".jsonl", ".ndjson":
+		return JSONLines
+	case ".yaml", ".yml":
+		return YAML
+	}
+	return Text
+}
+
+// Entry is a single entry read from the input, either a line of
+// text or a structured match record
+type Entry struct {
+	// Location is where in the input the entry came from, like "line 3"
+	Location string
+
+	// Line is the text of the entry, for the Text format
+	Line string
+
+	// Record is the match, for the structured formats
+	Record *games.MatchRecord
+}
+
+// AddTo adds the entry to the ranking
+func (e Entry) AddTo(r *games.Ranking) error {
+	if e.Record != nil {
+		return r.AddRecord(*e.Record)
+	}
+	return r.AddMatch(e.Line)
+}
+
+// Reader reads entries from match data
+type Reader interface {
+	// Next returns the next entry, or io.EOF when there are no more
+	Next() (Entry, error)
+}
+
+// NewReader returns a reader for the given format. The columns are
+// only used for the CSV format.
+func NewReader(in io.Reader, f Format, cols Columns) (Reader, error) {
+	switch f {
+	case Text:
+		return newTextReader(in), nil
+	case CSV:
+		return newCSVReader(in, cols)
+	case JSONLines:
+		return newJSONLinesReader(in), nil
+	case YAML:
+		return newYAMLReader(in)
+	}
+	return nil, fmt.Errorf("unknown input format '%v'", f)
+}
@@ -0,0 +1,214 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// readAll adds every entry from the reader to a new ranking
+func readAll(rd Reader) (*games.Ranking, error) {
+	r := games.NewRanking()
+	for {
+		e, err := rd.Next()
+		if err == io.EOF {
+			return r, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = e.AddTo(r); err != nil {
+			return nil, fmt.Errorf("%v: %w", e.Location, err)
+		}
+	}
+}
+
+func TestInput_DetectFormat(t *testing.T) {
+	tests := []struct {
+		path   string
+		expect Format
+	}{
+		{"matches.txt", Text},
+		{"matches", Text},
+		{"path/to/matches.CSV", CSV},
+		{"matches.jsonl", JSONLines},
+		{"matches.ndjson", JSONLines},
+		{"matches.yml", YAML},
+		{"matches.yaml", YAML},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got := DetectFormat(tt.path)
+			if got != tt.expect {
+				t.Errorf("wrong format for '%v', expected '%v' got '%v'", tt.path, tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestInput_ParseColumns(t *testing.T) {
+	tests := []struct {
+		in     []string
+		expect Columns
+		ok     bool
+	}{
+		{[]string{}, DefaultColumns, true},
+		{[]string{"team1=home", "score1 = hg"}, Columns{"home", "hg", "team2", "score2", "status", "venue"}, true},
+		{[]string{"home"}, Columns{}, false},
+		{[]string{"venue=ground"}, Columns{"team1", "score1", "team2", "score2", "status", "ground"}, true},
+		{[]string{"stadium=ground"}, Columns{}, false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got, err := ParseColumns(tt.in)
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected okay, got error: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("wrong columns, expected %+v got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestInput_SampleFiles(t *testing.T) {
+	expect, err := ioutil.ReadFile("../testdata/expected-output.txt")
+	if err != nil {
+		t.Fatalf("unable to read expected output: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		cols Columns
+	}{
+		{"../testdata/sample-input.txt", DefaultColumns},
+		{"../testdata/sample-input.csv", Columns{"home", "home_goals", "away", "away_goals", "status", "venue"}},
+		{"../testdata/sample-input.jsonl", DefaultColumns},
+		{"../testdata/sample-input.yaml", DefaultColumns},
+	}
+
+	for _, x := range tests {
+		tt := x
+		t.Run(tt.path, func(t *testing.T) {
+			f, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatalf("unable to open input: %v", err)
+			}
+			defer f.Close()
+
+			rd, err := NewReader(f, DetectFormat(tt.path), tt.cols)
+			if err != nil {
+				t.Fatalf("unable to create reader: %v", err)
+			}
+
+			r, err := readAll(rd)
+			if err != nil {
+				t.Fatalf("unable to read input: %v", err)
+			}
+
+			if got := r.Results(); got != string(expect) {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(string(expect), got))
+			}
+		})
+	}
+}
+
+func TestInput_Structured(t *testing.T) {
+	tests := []struct {
+		format Format
+		in     string
+		expect string
+		ok     bool
+	}{
+		{CSV, "team1,score1,team2,score2,status\n\"A, B\",1,C,0,\nD,,E,,P\n", "Matchday 1\nA, B, 3 pts\nC, 0 pts\nD, 0 pts\n", true},
+		{CSV, "team1,score1,team2\nA,1,B\n", "", false},
+		{CSV, "team1,score1,team2,score2\nA,one,B,2\n", "", false},
+		{CSV, "team1,score1,team2,score2\nA,,B,2\n", "", false},
+		{JSONLines, "{\"team1\":\"A\",\"score1\":2,\"team2\":\"B\",\"score2\":2}\n\n{\"team1\":\"C\",\"team2\":\"D\",\"status\":\"postponed\"}\n", "Matchday 1\nA, 1 pt\nB, 1 pt\nC, 0 pts\n", true},
+		{JSONLines, "{\"team1\":\"A\",\"score1\":2,\n", "", false},
+		{JSONLines, "{\"team1\":\"A\",\"score1\":2,\"team2\":\"B\",\"score2\":2,\"status\":\"NOPE\"}\n", "", false},
+		{YAML, "- team1: A\n  score1: 1\n  team2: B\n  score2: 0\n", "Matchday 1\nA, 3 pts\nB, 0 pts\n", true},
+		{YAML, "- team1: A\n  goals: 1\n", "", false},
+		{YAML, "team1: A\n", "", false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_%v_", i, tt.format), func(t *testing.T) {
+			rd, err := NewReader(strings.NewReader(tt.in), tt.format, DefaultColumns)
+			var r *games.Ranking
+			if err == nil {
+				r, err = readAll(rd)
+			}
+
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected okay, got error: %v", err)
+			}
+
+			if got := r.Results(); got != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, got))
+			}
+		})
+	}
+}
+
+// TestInput_Locations checks every format numbers lines the way an editor
+// does, from 1, so errors point at the right line of the file
+func TestInput_Locations(t *testing.T) {
+	tests := []struct {
+		format Format
+		in     string
+		expect []string
+	}{
+		{Text, "A 1, B 0\nC 1, D 1\n", []string{"line 1", "line 2"}},
+		{CSV, "team1,score1,team2,score2\nA,1,B,0\nC,1,D,1\n", []string{"line 2", "line 3"}},
+		{JSONLines, `{"team1":"A","score1":1,"team2":"B","score2":0}` + "\n\n" + `{"team1":"C","score1":1,"team2":"D","score2":1}` + "\n", []string{"line 1", "line 3"}},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			rd, err := NewReader(strings.NewReader(tt.in), tt.format, DefaultColumns)
+			if err != nil {
+				t.Fatalf("unable to create reader: %v", err)
+			}
+
+			got := []string{}
+			for {
+				e, err := rd.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("unable to read entry: %v", err)
+				}
+				got = append(got, e.Location)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.expect, "\n") {
+				t.Errorf("wrong locations\ndiff:\n%v", diff.LineDiff(strings.Join(tt.expect, "\n"), strings.Join(got, "\n")))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package input
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// maxJSONLine is the longest line the JSON Lines reader will accept
+const maxJSONLine = 1024 * 1024
+
+// jsonLinesReader reads match data with one JSON object per
+// line, blank lines are skipped
+type jsonLinesReader struct {
+	s  *bufio.Scanner
+	ln int
+}
+
+// newJSONLinesReader is the jsonLinesReader constructor
+func newJSONLinesReader(in io.Reader) *jsonLinesReader {
+	s := bufio.NewScanner(in)
+	s.Buffer(make([]byte, 0, 64*1024), maxJSONLine)
+	return &jsonLinesReader{s: s}
+}
+
+// Next returns the match from the next non-blank line
+func (jr *jsonLinesReader) Next() (Entry, error) {
+	for jr.s.Scan() {
+		jr.ln++
+		line := strings.TrimSpace(jr.s.Text())
+		if line == "" {
+			continue
+		}
+
+		e := Entry{Location: fmt.Sprintf("line %v", jr.ln)}
+		mr := games.MatchRecord{}
+		if err := json.Unmarshal([]byte(line), &mr); err != nil {
+			return e, fmt.Errorf("error parsing %v of match data: %w", e.Location, err)
+		}
+		e.Record = &mr
+		return e, nil
+	}
+
+	if err := jr.s.Err(); err != nil {
+		return Entry{}, fmt.Errorf("error processing match data: %w", err)
+	}
+	return Entry{}, io.EOF
+}
@@ -0,0 +1,33 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// textReader reads match data in the Text format, one match
+// or directive per line
+type textReader struct {
+	r  *bufio.Reader
+	ln int
+}
+
+// newTextReader is the textReader constructor
+func newTextReader(in io.Reader) *textReader {
+	return &textReader{r: bufio.NewReader(in)}
+}
+
+// Next returns the next line of input
+func (tr *textReader) Next() (Entry, error) {
+	line, _, err := tr.r.ReadLine()
+	if err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("error processing match data: %w", err)
+		}
+		return Entry{}, err
+	}
+
+	tr.ln++
+	return Entry{Location: fmt.Sprintf("line %v", tr.ln), Line: string(line)}, nil
+}
@@ -0,0 +1,47 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"gopkg.in/yaml.v2"
+)
+
+// yamlReader reads match data from a YAML list of matches:
+//
+//   - team1: Aptos FC
+//     score1: 2
+//     team2: Monterey United
+//     score2: 0
+type yamlReader struct {
+	matches []games.MatchRecord
+	next    int
+}
+
+// newYAMLReader is the yamlReader constructor, the whole document
+// is parsed up front
+func newYAMLReader(in io.Reader) (*yamlReader, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("error processing match data: %w", err)
+	}
+
+	yr := &yamlReader{}
+	if err = yaml.UnmarshalStrict(data, &yr.matches); err != nil {
+		return nil, fmt.Errorf("unable to parse YAML match data: %w", err)
+	}
+	return yr, nil
+}
+
+// Next returns the next match in the list
+func (yr *yamlReader) Next() (Entry, error) {
+	if yr.next >= len(yr.matches) {
+		return Entry{}, io.EOF
+	}
+
+	mr := yr.matches[yr.next]
+	yr.next++
+	return Entry{Location: fmt.Sprintf("match %v", yr.next), Record: &mr}, nil
+}
@@ -0,0 +1,174 @@
+package locale
+
+// Message keys used by the output
+const (
+	MsgMatchday     = "matchday"
+	MsgPoints       = "points"
+	MsgForfeit      = "forfeit"
+	MsgFinalTable   = "final_table"
+	MsgPosition     = "position"
+	MsgTeam         = "team"
+	MsgPointsCol    = "points_column"
+	MsgNote         = "note"
+	MsgHomeTable    = "home_table"
+	MsgAwayTable    = "away_table"
+	MsgFormTable    = "form_table"
+	MsgForm         = "form"
+	MsgSchedule     = "schedule"
+	MsgScheduleLeft = "schedule_left"
+)
+
+// the plural rules below are the CLDR rules for integers; rules that
+// only apply to decimals or compact numbers like "1M" are left out
+
+// pluralEnglish: one is 1
+func pluralEnglish(n int) PluralCategory {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+// pluralFrench: one is 0 or 1
+func pluralFrench(n int) PluralCategory {
+	if n == 0 || n == 1 {
+		return One
+	}
+	return Other
+}
+
+// pluralPortuguese is Brazilian Portuguese: one is 0 or 1
+func pluralPortuguese(n int) PluralCategory {
+	return pluralFrench(n)
+}
+
+var locales = map[string]*Locale{
+	"en": {
+		Tag:    "en",
+		plural: pluralEnglish,
+		messages: map[string]message{
+			MsgMatchday:     {Other: "Matchday %v"},
+			MsgPoints:       {One: "pt", Other: "pts"},
+			MsgForfeit:      {Other: "awarded %v-0 by forfeit"},
+			MsgFinalTable:   {Other: "Final Table"},
+			MsgPosition:     {Other: "Pos"},
+			MsgTeam:         {Other: "Team"},
+			MsgPointsCol:    {Other: "Pts"},
+			MsgNote:         {Other: "Note"},
+			MsgHomeTable:    {Other: "Home Table"},
+			MsgAwayTable:    {Other: "Away Table"},
+			MsgFormTable:    {Other: "Form Table, Matchday %v"},
+			MsgForm:         {Other: "Form"},
+			MsgSchedule:     {Other: "SoS"},
+			MsgScheduleLeft: {Other: "SoS left"},
+		},
+		group:          ",",
+		minGroupDigits: 1,
+		decimal:        ".",
+		months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		dateLayout:     "{month} {day}, {year}",
+	},
+	"es": {
+		Tag:    "es",
+		plural: pluralEnglish,
+		messages: map[string]message{
+			MsgMatchday:     {Other: "Jornada %v"},
+			MsgPoints:       {One: "pto", Other: "ptos"},
+			MsgForfeit:      {Other: "ganado %v-0 por incomparecencia"},
+			MsgFinalTable:   {Other: "Clasificación final"},
+			MsgPosition:     {Other: "Pos"},
+			MsgTeam:         {Other: "Equipo"},
+			MsgPointsCol:    {Other: "Ptos"},
+			MsgNote:         {Other: "Nota"},
+			MsgHomeTable:    {Other: "Clasificación como local"},
+			MsgAwayTable:    {Other: "Clasificación como visitante"},
+			MsgFormTable:    {Other: "Racha, jornada %v"},
+			MsgForm:         {Other: "Racha"},
+			MsgSchedule:     {Other: "Dific."},
+			MsgScheduleLeft: {Other: "Dific. rest."},
+		},
+		group:          ".",
+		minGroupDigits: 2,
+		decimal:        ",",
+		months:         [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		dateLayout:     "{day} de {month} de {year}",
+	},
+	"fr": {
+		Tag:    "fr",
+		plural: pluralFrench,
+		messages: map[string]message{
+			MsgMatchday:     {Other: "Journée %v"},
+			MsgPoints:       {One: "pt", Other: "pts"},
+			MsgForfeit:      {Other: "victoire %v-0 par forfait"},
+			MsgFinalTable:   {Other: "Classement final"},
+			MsgPosition:     {Other: "Pos"},
+			MsgTeam:         {Other: "Équipe"},
+			MsgPointsCol:    {Other: "Pts"},
+			MsgNote:         {Other: "Note"},
+			MsgHomeTable:    {Other: "Classement à domicile"},
+			MsgAwayTable:    {Other: "Classement à l'extérieur"},
+			MsgFormTable:    {Other: "Forme, journée %v"},
+			MsgForm:         {Other: "Forme"},
+			MsgSchedule:     {Other: "Diff."},
+			MsgScheduleLeft: {Other: "Diff. rest."},
+		},
+		// narrow no-break space
+		group:          "\u202f",
+		minGroupDigits: 1,
+		decimal:        ",",
+		months:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		dateLayout:     "{day} {month} {year}",
+	},
+	"pt": {
+		Tag:    "pt",
+		plural: pluralPortuguese,
+		messages: map[string]message{
+			MsgMatchday:     {Other: "Rodada %v"},
+			MsgPoints:       {One: "pt", Other: "pts"},
+			MsgForfeit:      {Other: "vitória por %v-0 por W.O."},
+			MsgFinalTable:   {Other: "Classificação final"},
+			MsgPosition:     {Other: "Pos"},
+			MsgTeam:         {Other: "Time"},
+			MsgPointsCol:    {Other: "Pts"},
+			MsgNote:         {Other: "Nota"},
+			MsgHomeTable:    {Other: "Classificação como mandante"},
+			MsgAwayTable:    {Other: "Classificação como visitante"},
+			MsgFormTable:    {Other: "Últimos jogos, rodada %v"},
+			MsgForm:         {Other: "Últimos jogos"},
+			MsgSchedule:     {Other: "Dific."},
+			MsgScheduleLeft: {Other: "Dific. rest."},
+		},
+		group:          ".",
+		minGroupDigits: 1,
+		decimal:        ",",
+		months:         [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		dateLayout:     "{day} de {month} de {year}",
+	},
+	"pt-pt": {
+		Tag: "pt-PT",
+		// European Portuguese uses the English rule, only 1 is singular
+		plural: pluralEnglish,
+		messages: map[string]message{
+			MsgMatchday:     {Other: "Jornada %v"},
+			MsgPoints:       {One: "pt", Other: "pts"},
+			MsgForfeit:      {Other: "vitória por %v-0 por falta de comparência"},
+			MsgFinalTable:   {Other: "Classificação final"},
+			MsgPosition:     {Other: "Pos"},
+			MsgTeam:         {Other: "Equipa"},
+			MsgPointsCol:    {Other: "Pts"},
+			MsgNote:         {Other: "Nota"},
+			MsgHomeTable:    {Other: "Classificação em casa"},
+			MsgAwayTable:    {Other: "Classificação fora"},
+			MsgFormTable:    {Other: "Forma, jornada %v"},
+			MsgForm:         {Other: "Forma"},
+			MsgSchedule:     {Other: "Dific."},
+			MsgScheduleLeft: {Other: "Dific. rest."},
+		},
+		// no-break space
+		group:          "\u00a0",
+		minGroupDigits: 2,
+		decimal:        ",",
+		months:         [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		dateLayout:     "{day} de {month} de {year}",
+	},
+}
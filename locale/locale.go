@@ -0,0 +1,170 @@
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default is the locale used when none is given, it produces
+// the same output as the original English text
+const Default = "en"
+
+// PluralCategory is a CLDR plural category, see
+// https://cldr.unicode.org/index/cldr-spec/plural-rules
+type PluralCategory string
+
+const (
+	Zero  PluralCategory = "zero"
+	One   PluralCategory = "one"
+	Two   PluralCategory = "two"
+	Few   PluralCategory = "few"
+	Many  PluralCategory = "many"
+	Other PluralCategory = "other"
+)
+
+// message is a single entry in a catalog, with a format string for
+// each plural category the language uses. Messages that don't depend
+// on a number only need Other.
+type message map[PluralCategory]string
+
+// Locale is the messages & formatting rules for a language
+type Locale struct {
+	// Tag is the language tag, like "en" or "pt-PT"
+	Tag string
+
+	// plural picks the CLDR plural category for an integer
+	plural func(n int) PluralCategory
+
+	messages map[string]message
+
+	// number formatting
+	group          string
+	minGroupDigits int
+	decimal        string
+	months         [12]string
+	dateLayout     string
+}
+
+// Get returns the locale for the given tag. Tags are matched without
+// caring about case or "-" vs "_", and a region that isn't known falls
+// back to the language, so "es_MX" gets "es".
+func Get(tag string) (*Locale, error) {
+	if tag == "" {
+		tag = Default
+	}
+
+	t := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if l, ok := locales[t]; ok {
+		return l, nil
+	}
+	if i := strings.Index(t, "-"); i > 0 {
+		if l, ok := locales[t[:i]]; ok {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown locale '%v', expected one of: %v", tag, strings.Join(Tags(), ", "))
+}
+
+// Tags returns the tag of every known locale
+func Tags() []string {
+	return []string{"en", "es", "fr", "pt", "pt-PT"}
+}
+
+// Plural returns the plural category for n, using the absolute
+// value so that -1 is treated the same as 1
+func (l *Locale) Plural(n int) PluralCategory {
+	if n < 0 {
+		n = -n
+	}
+	return l.plural(n)
+}
+
+// Message formats the message with the given key. Any args are
+// passed to fmt.Sprintf, the key is returned if it's not in the catalog.
+func (l *Locale) Message(key string, args ...interface{}) string {
+	m, ok := l.messages[key]
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(m[Other], args...)
+}
+
+// PluralMessage formats the message with the given key, picking the form
+// based on the plural category of n. The args are passed to fmt.Sprintf.
+func (l *Locale) PluralMessage(key string, n int, args ...interface{}) string {
+	m, ok := l.messages[key]
+	if !ok {
+		return key
+	}
+
+	f, ok := m[l.Plural(n)]
+	if !ok {
+		f = m[Other]
+	}
+	return fmt.Sprintf(f, args...)
+}
+
+// Number formats an integer with the locale's digit grouping,
+// 12345 is "12,345" in English and "12.345" in Spanish
+func (l *Locale) Number(n int) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+
+	digits := fmt.Sprintf("%d", n)
+	if len(digits) < 3+l.minGroupDigits {
+		return sign + digits
+	}
+
+	groups := []string{}
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+	return sign + strings.Join(groups, l.group)
+}
+
+// Decimal formats a number rounded to the given number of decimal places,
+// with the locale's digit grouping & decimal separator, 1234.5 is "1,234.5"
+// in English and "1234,5" in French
+func (l *Locale) Decimal(f float64, places int) string {
+	s := strconv.FormatFloat(math.Abs(f), 'f', places, 64)
+	whole, frac := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+
+	n, _ := strconv.Atoi(whole)
+	out := l.Number(n)
+	if frac != "" {
+		out += l.decimal + frac
+	}
+	if f < 0 && strings.Trim(whole+frac, "0") != "" {
+		out = "-" + out
+	}
+	return out
+}
+
+// SignedNumber is Number, but always includes the sign
+func (l *Locale) SignedNumber(n int) string {
+	if n >= 0 {
+		return "+" + l.Number(n)
+	}
+	return l.Number(n)
+}
+
+// Date formats a date in the locale's long date format. Match days don't
+// carry dates yet, this is here so output can use it once they do.
+func (l *Locale) Date(t time.Time) string {
+	r := strings.NewReplacer(
+		"{day}", fmt.Sprintf("%d", t.Day()),
+		"{month}", l.months[t.Month()-1],
+		"{year}", fmt.Sprintf("%d", t.Year()),
+	)
+	return r.Replace(l.dateLayout)
+}
@@ -0,0 +1,169 @@
+package locale
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLocale_Get(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect string
+		err    bool
+	}{
+		{"", "en", false},
+		{"en", "en", false},
+		{"EN_us", "en", false},
+		{"es-MX", "es", false},
+		{"fr", "fr", false},
+		{"pt", "pt", false},
+		{"pt-BR", "pt", false},
+		{"pt_pt", "pt-PT", false},
+		{"de", "", true},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			l, err := Get(tt.in)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected error, got locale '%v'", l.Tag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if l.Tag != tt.expect {
+				t.Errorf("wrong locale, expected '%v' got '%v'", tt.expect, l.Tag)
+			}
+		})
+	}
+}
+
+func TestLocale_Plural(t *testing.T) {
+	tests := []struct {
+		tag    string
+		n      int
+		expect string
+	}{
+		{"en", 0, "0 pts"},
+		{"en", 1, "1 pt"},
+		{"en", -1, "-1 pt"},
+		{"en", 2, "2 pts"},
+		{"es", 0, "0 ptos"},
+		{"es", 1, "1 pto"},
+		{"es", 5, "5 ptos"},
+		{"fr", 0, "0 pt"},
+		{"fr", 1, "1 pt"},
+		{"fr", 2, "2 pts"},
+		{"pt", 0, "0 pt"},
+		{"pt", 1, "1 pt"},
+		{"pt", 2, "2 pts"},
+		{"pt-PT", 0, "0 pts"},
+		{"pt-PT", 1, "1 pt"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			l, err := Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+			got := fmt.Sprintf("%v %v", tt.n, l.PluralMessage(MsgPoints, tt.n))
+			if got != tt.expect {
+				t.Errorf("wrong plural, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestLocale_Number(t *testing.T) {
+	tests := []struct {
+		tag    string
+		n      int
+		expect string
+	}{
+		{"en", 7, "7"},
+		{"en", 1234, "1,234"},
+		{"en", -1234567, "-1,234,567"},
+		{"es", 1234, "1234"},
+		{"es", 12345, "12.345"},
+		{"fr", 1234, "1\u202f234"},
+		{"pt", 1234, "1.234"},
+		{"pt-PT", 1234, "1234"},
+		{"pt-PT", 1234567, "1\u00a0234\u00a0567"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			l, err := Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+			if got := l.Number(tt.n); got != tt.expect {
+				t.Errorf("wrong number, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestLocale_Date(t *testing.T) {
+	d := time.Date(2021, time.August, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		tag    string
+		expect string
+	}{
+		{"en", "August 14, 2021"},
+		{"es", "14 de agosto de 2021"},
+		{"fr", "14 août 2021"},
+		{"pt", "14 de agosto de 2021"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			l, err := Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+			if got := l.Date(d); got != tt.expect {
+				t.Errorf("wrong date, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestLocale_Decimal(t *testing.T) {
+	tests := []struct {
+		tag    string
+		f      float64
+		places int
+		expect string
+	}{
+		{"en", 7.25, 1, "7.2"},
+		{"en", 1234.56, 2, "1,234.56"},
+		{"en", -0.04, 1, "0.0"},
+		{"en", -2.5, 0, "-2"},
+		{"es", 12345.5, 1, "12.345,5"},
+		{"fr", 1234.5, 1, "1\u202f234,5"},
+		{"pt-PT", 3.75, 2, "3,75"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			l, err := Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+			if got := l.Decimal(tt.f, tt.places); got != tt.expect {
+				t.Errorf("wrong decimal, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
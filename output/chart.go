@@ -0,0 +1,255 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// Chart is a type of chart that can be drawn for a season
+type Chart string
+
+const (
+	// BumpChart shows each team's position in the table after each match day
+	BumpChart Chart = "bump"
+	// PointsChart shows each team's points total after each match day
+	PointsChart Chart = "points"
+)
+
+// chart layout, in pixels
+const (
+	chartWidth   = 800
+	chartLeft    = 50
+	chartRight   = 190
+	chartTop     = 40
+	chartBottom  = 40
+	chartRowSize = 28
+)
+
+// chartColors are used for each team's line, in order, repeating
+// if there are more teams than colors
+var chartColors = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf", "#393b79", "#ad494a",
+}
+
+// ParseChart returns the chart with the given name
+func ParseChart(s string) (Chart, error) {
+	switch Chart(strings.ToLower(s)) {
+	case BumpChart:
+		return BumpChart, nil
+	case PointsChart:
+		return PointsChart, nil
+	}
+	return "", fmt.Errorf("unknown chart '%v', expected one of: %v, %v", s, BumpChart, PointsChart)
+}
+
+// chartSeries is the values for a single team, indexed by the
+// match day's position in the season; missing values are nil
+type chartSeries struct {
+	team   string
+	color  string
+	values []*int
+}
+
+// WriteChart draws the chart for the season as an SVG image
+func WriteChart(w io.Writer, c Chart, s games.Season) error {
+	var err error
+	switch c {
+	case BumpChart:
+		err = writeBumpChart(w, s)
+	case PointsChart:
+		err = writePointsChart(w, s)
+	default:
+		err = fmt.Errorf("unknown chart '%v'", c)
+	}
+	return err
+}
+
+// ChartSVG is WriteChart, but returns the SVG as a string
+func ChartSVG(c Chart, s games.Season) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := WriteChart(buf, c, s); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// chartData builds a series for each team using the given value
+// from each of the team's standings
+func chartData(s games.Season, value func(games.Standing) int) []chartSeries {
+	// teams are ordered by their final standing, so the legend
+	// reads the same as the table
+	order := []string{}
+	seen := map[string]bool{}
+	for i := len(s.Days) - 1; i >= 0; i-- {
+		for _, st := range s.Days[i].Standings {
+			if !seen[st.Team] {
+				order = append(order, st.Team)
+				seen[st.Team] = true
+			}
+		}
+	}
+
+	out := []chartSeries{}
+	for i, t := range order {
+		cs := chartSeries{team: t, color: chartColors[i%len(chartColors)], values: make([]*int, len(s.Days))}
+		for d, day := range s.Days {
+			for _, st := range day.Standings {
+				if st.Team == t {
+					v := value(st)
+					cs.values[d] = &v
+				}
+			}
+		}
+		out = append(out, cs)
+	}
+	return out
+}
+
+// writeBumpChart draws the position of each team after every match day
+func writeBumpChart(w io.Writer, s games.Season) error {
+	series := chartData(s, func(st games.Standing) int { return st.Position })
+	rows := len(series)
+	if rows < 2 {
+		rows = 2
+	}
+
+	plotH := (rows - 1) * chartRowSize
+	y := func(pos int) float64 {
+		return float64(chartTop + (pos-1)*plotH/(rows-1))
+	}
+
+	c := newSVGChart(len(s.Days), plotH, "Position after each matchday")
+	for p := 1; p <= len(series); p++ {
+		c.gridLine(y(p), fmt.Sprintf("%v", p))
+	}
+	c.dayLabels(s)
+	for _, cs := range series {
+		c.line(cs, y, true)
+	}
+	return c.write(w)
+}
+
+// writePointsChart draws the points total of each team after every match day
+func writePointsChart(w io.Writer, s games.Season) error {
+	series := chartData(s, func(st games.Standing) int { return st.Points })
+
+	lo, hi := 0, 1
+	for _, cs := range series {
+		for _, v := range cs.values {
+			if v != nil && *v < lo {
+				lo = *v
+			}
+			if v != nil && *v > hi {
+				hi = *v
+			}
+		}
+	}
+
+	step := int(math.Ceil(float64(hi-lo) / 10))
+	lo = int(math.Floor(float64(lo)/float64(step))) * step
+	hi = int(math.Ceil(float64(hi)/float64(step))) * step
+
+	plotH := 10 * chartRowSize
+	y := func(pts int) float64 {
+		return float64(chartTop) + float64(plotH)*float64(hi-pts)/float64(hi-lo)
+	}
+
+	c := newSVGChart(len(s.Days), plotH, "Points after each matchday")
+	for p := lo; p <= hi; p += step {
+		c.gridLine(y(p), fmt.Sprintf("%v", p))
+	}
+	c.dayLabels(s)
+	for i, cs := range series {
+		c.line(cs, y, false)
+		c.legend(i, cs)
+	}
+	return c.write(w)
+}
+
+// svgChart builds up the elements of a chart
+type svgChart struct {
+	days   int
+	height int
+	plotH  int
+	body   strings.Builder
+}
+
+// newSVGChart starts a chart with room for the given number of days
+func newSVGChart(days, plotH int, title string) *svgChart {
+	c := &svgChart{days: days, plotH: plotH, height: chartTop + plotH + chartBottom}
+	fmt.Fprintf(&c.body, `<text x="%v" y="20" font-size="16" font-weight="bold">%v</text>`+"\n", chartLeft, html.EscapeString(title))
+	return c
+}
+
+// x returns the horizontal position of the match day at index i
+func (c *svgChart) x(i int) float64 {
+	plotW := chartWidth - chartLeft - chartRight
+	if c.days <= 1 {
+		return float64(chartLeft + plotW/2)
+	}
+	return float64(chartLeft) + float64(i*plotW)/float64(c.days-1)
+}
+
+// gridLine draws a horizontal line across the chart with a label on the left
+func (c *svgChart) gridLine(y float64, label string) {
+	fmt.Fprintf(&c.body, `<line x1="%v" y1="%.1f" x2="%v" y2="%.1f" stroke="#ddd"/>`+"\n", chartLeft, y, chartWidth-chartRight, y)
+	fmt.Fprintf(&c.body, `<text x="%v" y="%.1f" font-size="12" text-anchor="end" dominant-baseline="middle">%v</text>`+"\n", chartLeft-8, y, label)
+}
+
+// dayLabels labels each match day along the bottom of the chart
+func (c *svgChart) dayLabels(s games.Season) {
+	by := chartTop + c.plotH + 24
+	for i, d := range s.Days {
+		fmt.Fprintf(&c.body, `<text x="%.1f" y="%v" font-size="12" text-anchor="middle">%v</text>`+"\n", c.x(i), by, d.Number)
+	}
+}
+
+// line draws a team's series, labelling the last point with the
+// team name if label is true
+func (c *svgChart) line(cs chartSeries, y func(int) float64, label bool) {
+	name := html.EscapeString(cs.team)
+	points := []string{}
+	lastX, lastY := -1.0, -1.0
+	for i, v := range cs.values {
+		if v == nil {
+			continue
+		}
+		px, py := c.x(i), y(*v)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", px, py))
+		fmt.Fprintf(&c.body, `<circle cx="%.1f" cy="%.1f" r="4" fill="%v"><title>%v: %v</title></circle>`+"\n", px, py, cs.color, name, *v)
+		lastX, lastY = px, py
+	}
+
+	if len(points) > 1 {
+		fmt.Fprintf(&c.body, `<polyline points="%v" fill="none" stroke="%v" stroke-width="2.5"/>`+"\n", strings.Join(points, " "), cs.color)
+	}
+	if label && lastX >= 0 {
+		fmt.Fprintf(&c.body, `<text x="%.1f" y="%.1f" font-size="12" fill="%v" dominant-baseline="middle">%v</text>`+"\n", lastX+10, lastY, cs.color, name)
+	}
+}
+
+// legend draws the i-th entry in the legend on the right of the chart
+func (c *svgChart) legend(i int, cs chartSeries) {
+	lx := chartWidth - chartRight + 20
+	ly := chartTop + i*20
+	fmt.Fprintf(&c.body, `<rect x="%v" y="%v" width="12" height="12" fill="%v"/>`+"\n", lx, ly-6, cs.color)
+	fmt.Fprintf(&c.body, `<text x="%v" y="%v" font-size="12" dominant-baseline="middle">%v</text>`+"\n", lx+18, ly, html.EscapeString(cs.team))
+
+	if h := ly + chartBottom; h > c.height {
+		c.height = h
+	}
+}
+
+// write outputs the finished SVG
+func (c *svgChart) write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v" font-family="sans-serif">`+"\n%v</svg>\n",
+		chartWidth, c.height, chartWidth, c.height, c.body.String())
+	return err
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// checkSVG makes sure the chart is well formed XML with an svg root
+func checkSVG(t *testing.T, svg string) {
+	d := xml.NewDecoder(strings.NewReader(svg))
+	root := ""
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("chart isn't valid XML: %v", err)
+		}
+		if se, ok := tok.(xml.StartElement); ok && root == "" {
+			root = se.Name.Local
+		}
+	}
+	if root != "svg" {
+		t.Errorf("expected svg root element, got '%v'", root)
+	}
+}
+
+func TestOutput_Chart_ParseChart(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect Chart
+		ok     bool
+	}{
+		{"bump", BumpChart, true},
+		{"Points", PointsChart, true},
+		{"pie", "", false},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got, err := ParseChart(tt.in)
+			if tt.ok != (err == nil) {
+				t.Fatalf("expected okay: %v, got error: %v", tt.ok, err)
+			}
+			if got != tt.expect {
+				t.Errorf("wrong chart, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestOutput_Chart_WriteChart(t *testing.T) {
+	sample := sampleSeason(t)
+
+	escaped := games.NewRanking()
+	for _, l := range []string{"<Tom & Jerry> 1, B 0", `!deduct "B" 5`} {
+		if err := escaped.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		chart    Chart
+		season   games.Season
+		contains []string
+		lines    int
+	}{
+		{"bump_sample", BumpChart, sample, []string{"Position after each matchday", ">Aptos FC</text>"}, 6},
+		{"points_sample", PointsChart, sample, []string{"Points after each matchday", "<title>Aptos FC: 9</title>"}, 6},
+		{"bump_escaped", BumpChart, escaped.Model(), []string{"&lt;Tom &amp; Jerry&gt;"}, 0},
+		{"points_negative", PointsChart, escaped.Model(), []string{"<title>B: -5</title>", ">-5</text>"}, 0},
+		{"bump_empty", BumpChart, games.Season{}, []string{}, 0},
+		{"points_empty", PointsChart, games.Season{}, []string{}, 0},
+	}
+
+	for _, x := range tests {
+		tt := x
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := ChartSVG(tt.chart, tt.season)
+			if err != nil {
+				t.Fatalf("unable to draw chart: %v", err)
+			}
+			checkSVG(t, svg)
+
+			for _, c := range tt.contains {
+				if !strings.Contains(svg, c) {
+					t.Errorf("expected chart to contain '%v'", c)
+				}
+			}
+			if got := strings.Count(svg, "<polyline"); got != tt.lines {
+				t.Errorf("expected %v lines, got %v", tt.lines, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// DayWriter writes results a match day at a time, so each day can be
+// written as soon as it's complete instead of waiting for the whole season
+type DayWriter interface {
+	// WriteDay writes the results for the next match day
+	WriteDay(d games.Day) error
+
+	// Close writes anything that comes after the last match day
+	Close() error
+}
+
+// writeSeason writes every day in the season with the day writer
+func writeSeason(dw DayWriter, s games.Season) error {
+	for _, d := range s.Days {
+		if err := dw.WriteDay(d); err != nil {
+			return err
+		}
+	}
+	return dw.Close()
+}
+
+// textWriter is the DayWriter for the Text format
+type textWriter struct {
+	w   io.Writer
+	loc *locale.Locale
+
+	// schedules is the standings from the last day written if they have
+	// the strength of schedule, for the final table
+	schedules []games.Standing
+	written   bool
+}
+
+// NewTextWriter returns a DayWriter for the plain text format, see WriteText
+func NewTextWriter(w io.Writer, loc *locale.Locale) DayWriter {
+	return &textWriter{w: w, loc: loc}
+}
+
+// WriteDay ...
+func (tw *textWriter) WriteDay(d games.Day) error {
+	if tw.written {
+		if _, err := io.WriteString(tw.w, "\n"); err != nil {
+			return err
+		}
+	}
+	tw.written = true
+	tw.schedules = nil
+	for _, st := range d.Standings {
+		if st.Schedule != nil {
+			tw.schedules = d.Standings
+			break
+		}
+	}
+
+	if _, err := fmt.Fprintf(tw.w, "%v\n", tw.loc.Message(locale.MsgMatchday, d.Number)); err != nil {
+		return err
+	}
+
+	for _, st := range d.Leaders(DayLeaders) {
+		note := standingNote(tw.loc, st)
+		if note != "" {
+			note = fmt.Sprintf(" (%v)", note)
+		}
+
+		_, err := fmt.Fprintf(tw.w, "%v, %v %v%v\n", teamLabel(st), tw.loc.Number(st.Points), tw.loc.PluralMessage(locale.MsgPoints, st.Points), note)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the final table with each team's strength of schedule if
+// the last day has it, only the leaders are listed for each match day
+func (tw *textWriter) Close() error {
+	if tw.schedules == nil {
+		return nil
+	}
+	if _, err := fmt.Fprintf(tw.w, "\n%v\n", tw.loc.Message(locale.MsgFinalTable)); err != nil {
+		return err
+	}
+
+	for _, st := range tw.schedules {
+		line := fmt.Sprintf("%v, %v %v", teamLabel(st), tw.loc.Number(st.Points), tw.loc.PluralMessage(locale.MsgPoints, st.Points))
+		if sos := scheduleCell(tw.loc, st.Schedule, false); sos != "" {
+			line += fmt.Sprintf(", %v %v", tw.loc.Message(locale.MsgSchedule), sos)
+		}
+		if left := scheduleCell(tw.loc, st.Schedule, true); left != "" {
+			line += fmt.Sprintf(", %v %v", tw.loc.Message(locale.MsgScheduleLeft), left)
+		}
+		if note := standingNote(tw.loc, st); note != "" {
+			line += fmt.Sprintf(" (%v)", note)
+		}
+		if _, err := fmt.Fprintln(tw.w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// terminalWriter is the DayWriter for terminal tables
+type terminalWriter struct {
+	w    io.Writer
+	loc  *locale.Locale
+	opts TerminalOptions
+
+	// prev is the standings from the day before, for the movement arrows
+	prev    []games.Standing
+	written bool
+}
+
+// NewTerminalWriter returns a DayWriter for terminal tables, see WriteTerminal
+func NewTerminalWriter(w io.Writer, loc *locale.Locale, opts TerminalOptions) DayWriter {
+	return &terminalWriter{w: w, loc: loc, opts: opts, prev: []games.Standing{}}
+}
+
+// WriteDay ...
+func (tw *terminalWriter) WriteDay(d games.Day) error {
+	if tw.written {
+		if _, err := io.WriteString(tw.w, "\n"); err != nil {
+			return err
+		}
+	}
+	tw.written = true
+
+	au := aurora.NewAurora(tw.opts.Color)
+	if _, err := fmt.Fprintf(tw.w, "%v\n", au.Bold(tw.loc.Message(locale.MsgMatchday, d.Number))); err != nil {
+		return err
+	}
+
+	if err := WriteStandings(tw.w, tw.loc, d.Standings, tw.prev, tw.opts); err != nil {
+		return err
+	}
+	tw.prev = d.Standings
+	return nil
+}
+
+// Close ...
+func (tw *terminalWriter) Close() error {
+	return nil
+}
+
+// markupWriter is the DayWriter for the Markdown & reStructuredText formats
+type markupWriter struct {
+	w   io.Writer
+	f   Format
+	loc *locale.Locale
+
+	// last is the standings from the last day written, for the final table
+	last    []games.Standing
+	written bool
+}
+
+// NewMarkupWriter returns a DayWriter for one of the markup formats,
+// see WriteMarkup
+func NewMarkupWriter(w io.Writer, f Format, loc *locale.Locale) (DayWriter, error) {
+	if f != GFM && f != Markdown && f != RST {
+		return nil, fmt.Errorf("'%v' isn't a markup format", f)
+	}
+	return &markupWriter{w: w, f: f, loc: loc}, nil
+}
+
+// WriteDay ...
+func (mw *markupWriter) WriteDay(d games.Day) error {
+	mw.last = d.Standings
+	return mw.section(mw.loc.Message(locale.MsgMatchday, d.Number), d.Leaders(DayLeaders))
+}
+
+// Close writes the final table, the full standings from the last day
+func (mw *markupWriter) Close() error {
+	if mw.last == nil {
+		return nil
+	}
+	return mw.section(mw.loc.Message(locale.MsgFinalTable), mw.last)
+}
+
+// section writes a titled table of standings
+func (mw *markupWriter) section(title string, standings []games.Standing) error {
+	if mw.written {
+		if _, err := io.WriteString(mw.w, "\n"); err != nil {
+			return err
+		}
+	}
+	mw.written = true
+
+	var err error
+	if mw.f == RST {
+		title = escapeMarkup(title)
+		_, err = fmt.Fprintf(mw.w, "%v\n%v\n\n", title, strings.Repeat("=", len(title)))
+	} else {
+		_, err = fmt.Fprintf(mw.w, "## %v\n\n", escapeMarkup(title))
+	}
+	if err != nil {
+		return err
+	}
+
+	t := standingsTable(mw.loc, standings)
+	if mw.f == RST {
+		return t.writeRST(mw.w)
+	}
+	return t.writeMarkdown(mw.w, mw.f == GFM)
+}
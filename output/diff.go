@@ -0,0 +1,104 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// WriteDiff writes the changes between two rankings as plain text. Each
+// match day that changed gets a line for every match removed ( - ), added
+// ( + ) or changed ( ~ ), including matches moved from another day, then a
+// table of the teams whose standing moved.
+func WriteDiff(w io.Writer, d games.RankingDiff) error {
+	if d.Same() {
+		_, err := io.WriteString(w, "No differences\n")
+		return err
+	}
+
+	for i, dd := range d.Days {
+		var b strings.Builder
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Matchday %v\n", dd.Day)
+		for _, m := range dd.Removed {
+			fmt.Fprintf(&b, "- %v\n", diffMatch(m))
+		}
+		for _, m := range dd.Added {
+			fmt.Fprintf(&b, "+ %v\n", diffMatch(m))
+		}
+		for _, c := range dd.Changed {
+			fmt.Fprintf(&b, "~ %v -> %v", diffMatch(c.Old), diffMatch(c.New))
+			if c.OldDay != 0 {
+				fmt.Fprintf(&b, ", moved from matchday %v", c.OldDay)
+			}
+			b.WriteString("\n")
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+
+		if len(dd.Standings) == 0 {
+			continue
+		}
+		if len(dd.Removed)+len(dd.Added)+len(dd.Changed) > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		t := table{
+			headers: []string{"Team", "Pos", "Pts"},
+			aligns:  []align{alignLeft, alignLeft, alignLeft},
+		}
+		for _, s := range dd.Standings {
+			t.rows = append(t.rows, []string{s.Team, shift(s.OldPosition, s.NewPosition, s.OldPosition == 0, s.NewPosition == 0), shift(s.OldPoints, s.NewPoints, s.OldPosition == 0, s.NewPosition == 0)})
+		}
+		if err := t.writeTerminal(w, func(row, col int, cell string) string { return cell }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffMatch writes a match the way it's written in a match line, along
+// with anything else about it that can change
+func diffMatch(m games.Match) string {
+	s := fmt.Sprintf("%v %v, %v %v", m.Team1, m.Score1, m.Team2, m.Score2)
+	if m.Venue != "" {
+		s += " @ " + m.Venue
+	}
+
+	extra := []string{}
+	if m.Status != "played" {
+		extra = append(extra, m.Status)
+	}
+	if m.Forfeit != "" {
+		extra = append(extra, "forfeit by "+m.Forfeit)
+	}
+	if m.RescheduledFrom != 0 {
+		extra = append(extra, fmt.Sprintf("from day %v", m.RescheduledFrom))
+	}
+	if len(extra) > 0 {
+		s += fmt.Sprintf(" (%v)", strings.Join(extra, ", "))
+	}
+	return s
+}
+
+// shift writes how a number changed, like "1 -> 2", with "-" for the side
+// the team isn't in & just the number if it didn't change
+func shift(from, to int, fromMissing, toMissing bool) string {
+	f, t := fmt.Sprint(from), fmt.Sprint(to)
+	if fromMissing {
+		f = "-"
+	}
+	if toMissing {
+		t = "-"
+	}
+	if f == t {
+		return t
+	}
+	return f + " -> " + t
+}
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+func TestOutput_Diff_Shift(t *testing.T) {
+	tests := []struct {
+		from, to               int
+		fromMissing, toMissing bool
+		expect                 string
+	}{
+		{1, 2, false, false, "1 -> 2"},
+		{3, 3, false, false, "3"},
+		{0, 4, true, false, "- -> 4"},
+		{2, 0, false, true, "2 -> -"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if got := shift(tt.from, tt.to, tt.fromMissing, tt.toMissing); got != tt.expect {
+				t.Errorf("wrong shift, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestOutput_Diff_WriteDiff(t *testing.T) {
+	from, to := games.NewRanking(), games.NewRanking()
+	for _, l := range []string{"A 1, B 0", "C 1, D 1", "A 1, C 0", "B, D P"} {
+		if err := from.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	for _, l := range []string{"A 1, B 2", "C 1, D 1", "A 1, C 0", "B 0, D 2 @ Hampden"} {
+		if err := to.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+
+	expect := `Matchday 1
+~ A 1, B 0 -> A 1, B 2
+
+Team  Pos     Pts
+B     4 -> 1  0 -> 3
+A     1 -> 4  3 -> 0
+
+Matchday 2
+~ B 0, D 0 (postponed) -> B 0, D 2 @ Hampden
+
+Team  Pos     Pts
+D     3 -> 1  1 -> 4
+A     1 -> 2  6 -> 3
+B     4 -> 3  0 -> 3
+C     2 -> 4  1
+`
+	out := &bytes.Buffer{}
+	if err := WriteDiff(out, games.Diff(from, to)); err != nil {
+		t.Fatalf("unable to write diff: %v", err)
+	}
+	if out.String() != expect {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+
+	// matches moved to another day
+	before, after := games.NewRanking(), games.NewRanking()
+	for _, l := range []string{"A 1, B 0", "C 1, D 1", "A 1, C 0", "B 2, D 2", "A 0, D 1", "B 1, C 1"} {
+		if err := before.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	for _, l := range []string{"A 1, B 0", "C 1, D 1", "A 0, D 1", "B 1, C 1", "A 1, C 0", "B 2, D 2"} {
+		if err := after.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	expect = `Matchday 2
+~ A 0, D 1 -> A 0, D 1, moved from matchday 3
+~ B 1, C 1 -> B 1, C 1, moved from matchday 3
+
+Team  Pos     Pts
+D     2 -> 1  2 -> 4
+A     1 -> 2  6 -> 3
+C     4 -> 3  1 -> 2
+B     3 -> 4  1
+
+Matchday 3
+~ A 1, C 0 -> A 1, C 0, moved from matchday 2
+~ B 2, D 2 -> B 2, D 2, moved from matchday 2
+`
+	out.Reset()
+	if err := WriteDiff(out, games.Diff(before, after)); err != nil {
+		t.Fatalf("unable to write diff: %v", err)
+	}
+	if out.String() != expect {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+
+	out.Reset()
+	if err := WriteDiff(out, games.Diff(from, from)); err != nil {
+		t.Fatalf("unable to write diff: %v", err)
+	}
+	if out.String() != "No differences\n" {
+		t.Errorf("expected no differences, got '%v'", out.String())
+	}
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// WriteForm writes the form table at the end of the given match day, see
+// games.Ranking.Form, as aligned columns for the Text format or a table
+// in one of the markup formats
+func WriteForm(w io.Writer, f Format, loc *locale.Locale, day int, form []games.FormStanding) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+
+	t := table{
+		headers: []string{loc.Message(locale.MsgPosition), loc.Message(locale.MsgTeam), loc.Message(locale.MsgPointsCol), loc.Message(locale.MsgForm)},
+		aligns:  []align{alignRight, alignLeft, alignRight, alignLeft},
+	}
+	for _, fs := range form {
+		t.rows = append(t.rows, []string{loc.Number(fs.Position), fs.Team, loc.Number(fs.Points), fs.Form})
+	}
+
+	title := loc.Message(locale.MsgFormTable, day)
+	switch f {
+	case Text:
+		if _, err := fmt.Fprintf(w, "%v\n%v\n", title, strings.Repeat("-", utf8.RuneCountInString(title))); err != nil {
+			return err
+		}
+		return t.writeTerminal(w, func(row, col int, cell string) string { return cell })
+	case RST:
+		title = escapeMarkup(title)
+		if _, err := fmt.Fprintf(w, "%v\n%v\n\n", title, strings.Repeat("=", len(title))); err != nil {
+			return err
+		}
+		return t.writeRST(w)
+	}
+
+	if _, err := fmt.Fprintf(w, "## %v\n\n", escapeMarkup(title)); err != nil {
+		return err
+	}
+	return t.writeMarkdown(w, f == GFM)
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+func TestOutput_Form(t *testing.T) {
+	form := []games.FormStanding{
+		{Position: 1, Team: "Aptos FC", Points: 9, Form: "LWWW"},
+		{Position: 2, Team: "Lions", Points: 1, Form: "D"},
+	}
+
+	tests := []struct {
+		f      Format
+		tag    string
+		expect string
+	}{
+		{Text, "en", `Form Table, Matchday 4
+----------------------
+Pos  Team      Pts  Form
+  1  Aptos FC    9  LWWW
+  2  Lions       1  D
+`},
+		{GFM, "es", `## Racha, jornada 4
+
+| Pos | Equipo   | Ptos | Racha |
+| --: | :------- | ---: | :---- |
+|   1 | Aptos FC |    9 | LWWW  |
+|   2 | Lions    |    1 | D     |
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			loc, err := locale.Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err = WriteForm(out, tt.f, loc, 4, form); err != nil {
+				t.Fatalf("unable to write form table: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes the whole season, or anything else from the games
+// package like a RankingDiff, as indented JSON using the field names
+// from its json tags
+func WriteJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+func TestOutput_JSON_WriteJSON(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"A 1, B 0", `!deduct "B" 1 "late"`} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	schedules, err := r.Schedules(nil)
+	if err != nil {
+		t.Fatalf("unable to work out schedules: %v", err)
+	}
+	m := r.Model()
+	m.Days[0] = m.Days[0].WithSchedules(schedules)
+
+	expect := `{
+  "teams": [
+    "A",
+    "B"
+  ],
+  "days": [
+    {
+      "number": 1,
+      "matches": [
+        {
+          "team1": "A",
+          "score1": 1,
+          "team2": "B",
+          "score2": 0,
+          "status": "played"
+        }
+      ],
+      "standings": [
+        {
+          "position": 1,
+          "team": "A",
+          "points": 3,
+          "schedule": {
+            "played": 1,
+            "past": -1,
+            "remaining": 0,
+            "future": 0
+          }
+        },
+        {
+          "position": 2,
+          "team": "B",
+          "points": -1,
+          "note": "-1 pt: late",
+          "adjustments": [
+            {
+              "points": -1,
+              "reason": "late"
+            }
+          ],
+          "schedule": {
+            "played": 1,
+            "past": 3,
+            "remaining": 0,
+            "future": 0
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+	out := &bytes.Buffer{}
+	if err := WriteJSON(out, m); err != nil {
+		t.Fatalf("unable to write JSON: %v", err)
+	}
+	if out.String() != expect {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+}
+
+// TestOutput_JSON_WriteJSON_Races checks the race is only written for the
+// standings it's been worked out for
+func TestOutput_JSON_WriteJSON_Races(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"A 1, B 0", "C 1, D 0", "A 1, C 0", "B 0, D 0"} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	m := r.Model()
+	m.Days[1] = m.Days[1].WithRaces(map[string]games.Race{"A": games.RaceClinched, "B": games.RaceOpen, "C": games.RaceOpen, "D": games.RaceEliminated})
+
+	out := &bytes.Buffer{}
+	if err := WriteJSON(out, m); err != nil {
+		t.Fatalf("unable to write JSON: %v", err)
+	}
+	expect := []string{`"race": "clinched"`, `"race": "open"`, `"race": "open"`, `"race": "eliminated"`}
+	if got := raceLines(out.String()); strings.Join(got, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("wrong races\ndiff:\n%v", diff.LineDiff(strings.Join(expect, "\n"), strings.Join(got, "\n")))
+	}
+}
+
+// raceLines returns the race fields in the JSON, in order
+func raceLines(out string) []string {
+	got := []string{}
+	for _, l := range strings.Split(out, "\n") {
+		if l = strings.TrimSuffix(strings.TrimSpace(l), ","); strings.HasPrefix(l, `"race"`) {
+			got = append(got, l)
+		}
+	}
+	return got
+}
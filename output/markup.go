@@ -0,0 +1,111 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// Format is a way of writing out the results
+type Format string
+
+const (
+	// Text is the original plain text output
+	Text Format = "text"
+	// GFM is GitHub flavored Markdown tables
+	GFM Format = "gfm"
+	// Markdown is plain Markdown pipe tables
+	Markdown Format = "markdown"
+	// RST is reStructuredText grid tables
+	RST Format = "rst"
+)
+
+// Formats is every supported output format
+var Formats = []Format{Text, GFM, Markdown, RST}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format '%v', expected one of: %v", s, Formats)
+}
+
+// DayLeaders is how many teams are shown in the table for each match
+// day, the final table shows every team
+const DayLeaders = 3
+
+// WriteMarkup writes the standings for each match day, followed by
+// the final table, as Markdown or reStructuredText translated for the locale
+func WriteMarkup(w io.Writer, f Format, loc *locale.Locale, s games.Season) error {
+	mw, err := NewMarkupWriter(w, f, loc)
+	if err != nil {
+		return err
+	}
+	return writeSeason(mw, s)
+}
+
+// standingsTable builds a table from the standings, the note
+// column is only included if a team has a note, and the strength of
+// schedule columns if the standings have one
+func standingsTable(loc *locale.Locale, st []games.Standing) table {
+	t := table{
+		headers: []string{loc.Message(locale.MsgPosition), loc.Message(locale.MsgTeam), loc.Message(locale.MsgPointsCol)},
+		aligns:  []align{alignRight, alignLeft, alignRight},
+	}
+
+	notes, schedule, remaining := false, false, false
+	for _, s := range st {
+		notes = notes || standingNote(loc, s) != ""
+		if s.Schedule != nil {
+			schedule = true
+			remaining = remaining || s.Schedule.Remaining > 0
+		}
+	}
+	if schedule {
+		t.headers = append(t.headers, loc.Message(locale.MsgSchedule))
+		t.aligns = append(t.aligns, alignRight)
+	}
+	if remaining {
+		t.headers = append(t.headers, loc.Message(locale.MsgScheduleLeft))
+		t.aligns = append(t.aligns, alignRight)
+	}
+	if notes {
+		t.headers = append(t.headers, loc.Message(locale.MsgNote))
+		t.aligns = append(t.aligns, alignLeft)
+	}
+
+	for _, s := range st {
+		r := []string{loc.Number(s.Position), teamLabel(s), loc.Number(s.Points)}
+		if schedule {
+			r = append(r, scheduleCell(loc, s.Schedule, false))
+		}
+		if remaining {
+			r = append(r, scheduleCell(loc, s.Schedule, true))
+		}
+		if notes {
+			r = append(r, standingNote(loc, s))
+		}
+		t.rows = append(t.rows, r)
+	}
+	return t
+}
+
+// scheduleCell is the strength of schedule for a standing, for the
+// matches played or the ones left, empty if there aren't any
+func scheduleCell(loc *locale.Locale, sc *games.Schedule, left bool) string {
+	switch {
+	case sc == nil:
+		return ""
+	case left && sc.Remaining > 0:
+		return loc.Decimal(sc.Future, 1)
+	case !left && sc.Played > 0:
+		return loc.Decimal(sc.Past, 1)
+	}
+	return ""
+}
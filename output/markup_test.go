@@ -0,0 +1,206 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+func TestOutput_Markup_EscapeMarkup(t *testing.T) {
+	tests := []struct {
+		in, expect string
+	}{
+		{"Aptos FC", "Aptos FC"},
+		{"A|B", `A\|B`},
+		{`*Stars* _of_ \ [Town]`, `\*Stars\* \_of\_ \\ \[Town\]`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if got := escapeMarkup(tt.in); got != tt.expect {
+				t.Errorf("wrong escaping, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestOutput_Markup_WriteMarkup(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{`"Pipe|Team" 1, B 0`, "C 1, D 1", `!deduct "B" 1 "late"`, `"Pipe|Team" | 0 | C | 2`} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+
+	en, err := locale.Get("en")
+	if err != nil {
+		t.Fatalf("unable to get locale: %v", err)
+	}
+
+	tests := []struct {
+		format Format
+		expect string
+	}{
+		{GFM, `## Matchday 1
+
+| Pos | Team       | Pts |
+| --: | :--------- | --: |
+|   1 | Pipe\|Team |   3 |
+|   2 | C          |   1 |
+|   3 | D          |   1 |
+
+## Matchday 2
+
+| Pos | Team       | Pts |
+| --: | :--------- | --: |
+|   1 | C          |   4 |
+|   2 | Pipe\|Team |   3 |
+
+## Final Table
+
+| Pos | Team       | Pts |
+| --: | :--------- | --: |
+|   1 | C          |   4 |
+|   2 | Pipe\|Team |   3 |
+`},
+		{Markdown, `## Matchday 1
+
+Pos | Team       | Pts
+--- | ---------- | ---
+  1 | Pipe\|Team |   3
+  2 | C          |   1
+  3 | D          |   1
+
+## Matchday 2
+
+Pos | Team       | Pts
+--- | ---------- | ---
+  1 | C          |   4
+  2 | Pipe\|Team |   3
+
+## Final Table
+
+Pos | Team       | Pts
+--- | ---------- | ---
+  1 | C          |   4
+  2 | Pipe\|Team |   3
+`},
+		{RST, `Matchday 1
+==========
+
++-----+------------+-----+
+| Pos | Team       | Pts |
++=====+============+=====+
+|   1 | Pipe\|Team |   3 |
++-----+------------+-----+
+|   2 | C          |   1 |
++-----+------------+-----+
+|   3 | D          |   1 |
++-----+------------+-----+
+
+Matchday 2
+==========
+
++-----+------------+-----+
+| Pos | Team       | Pts |
++=====+============+=====+
+|   1 | C          |   4 |
++-----+------------+-----+
+|   2 | Pipe\|Team |   3 |
++-----+------------+-----+
+
+Final Table
+===========
+
++-----+------------+-----+
+| Pos | Team       | Pts |
++=====+============+=====+
+|   1 | C          |   4 |
++-----+------------+-----+
+|   2 | Pipe\|Team |   3 |
++-----+------------+-----+
+`},
+	}
+
+	for _, x := range tests {
+		tt := x
+		t.Run(string(tt.format), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := WriteMarkup(out, tt.format, en, r.Model()); err != nil {
+				t.Fatalf("unable to write markup: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+
+	if err := WriteMarkup(&bytes.Buffer{}, Text, en, r.Model()); err == nil {
+		t.Errorf("expected error for non-markup format, got nothing")
+	}
+}
+
+func TestOutput_Markup_Notes(t *testing.T) {
+	st := []games.Standing{{Position: 1, Team: "A", Points: 3}, {Position: 2, Team: "B", Points: -1, Note: "-1 pt: late", Adjustments: []games.Adjustment{{Points: -1, Reason: "late"}}}}
+	en, _ := locale.Get("en")
+	tb := standingsTable(en, st)
+	if len(tb.headers) != 4 || tb.rows[1][3] != "-1 pt: late" {
+		t.Errorf("expected note column, got headers %v and rows %v", tb.headers, tb.rows)
+	}
+
+	tb = standingsTable(en, st[:1])
+	if len(tb.headers) != 3 {
+		t.Errorf("expected no note column, got headers %v", tb.headers)
+	}
+}
+
+func TestOutput_Markup_Schedule(t *testing.T) {
+	fr, _ := locale.Get("fr")
+
+	tests := []struct {
+		st     []games.Standing
+		expect string
+	}{
+		{
+			[]games.Standing{
+				{Position: 1, Team: "A", Points: 3, Schedule: &games.Schedule{Played: 2, Past: 1.4, Remaining: 1, Future: 4}},
+				{Position: 2, Team: "B", Points: -1, Adjustments: []games.Adjustment{{Points: -1, Reason: "late"}}, Schedule: &games.Schedule{Played: 2, Past: 3}},
+			},
+			`| Pos | Équipe | Pts | Diff. | Diff. rest. | Note        |
+| --: | :----- | --: | ----: | ----------: | :---------- |
+|   1 | A      |   3 |   1,4 |         4,0 |             |
+|   2 | B      |  -1 |   3,0 |             | -1 pt: late |
+`,
+		},
+		{
+			// nothing left to play, so there's no remaining column
+			[]games.Standing{
+				{Position: 1, Team: "A", Points: 3, Schedule: &games.Schedule{Played: 1, Past: 2.5}},
+				{Position: 2, Team: "B", Points: 0, Schedule: &games.Schedule{}},
+			},
+			`| Pos | Équipe | Pts | Diff. |
+| --: | :----- | --: | ----: |
+|   1 | A      |   3 |   2,5 |
+|   2 | B      |   0 |       |
+`,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := standingsTable(fr, tt.st).writeMarkdown(out, true); err != nil {
+				t.Fatalf("unable to write table: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
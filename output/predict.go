@@ -0,0 +1,110 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// WritePredictions writes the chance of each result for every prediction,
+// along with the expected goals & the most likely scorelines, listing up to
+// scorelines of them for each match
+func WritePredictions(w io.Writer, f Format, preds []games.Prediction, scorelines int) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+
+	t := table{
+		headers: []string{"Match", "Expected goals", "Win", "Draw", "Loss", "Likely scores"},
+		aligns:  []align{alignLeft, alignRight, alignRight, alignRight, alignRight, alignLeft},
+	}
+	for _, p := range preds {
+		match := fmt.Sprintf("%v v %v", p.Team1, p.Team2)
+		if p.Neutral {
+			match += " (neutral)"
+		}
+
+		likely := []string{}
+		for _, s := range p.Likely(scorelines) {
+			likely = append(likely, fmt.Sprintf("%v-%v %v", s.Score1, s.Score2, percent(s.Probability)))
+		}
+
+		t.rows = append(t.rows, []string{
+			match,
+			fmt.Sprintf("%.2f-%.2f", p.Expected1, p.Expected2),
+			percent(p.Win1), percent(p.Draw), percent(p.Win2),
+			strings.Join(likely, ", "),
+		})
+	}
+	return writeSections(w, f, []section{{"Predictions", t}})
+}
+
+// percent writes a chance between 0 & 1 as a percentage
+func percent(p float64) string {
+	return fmt.Sprintf("%.1f%%", 100*p)
+}
+
+// WriteStrengths writes the fitted model: the league average & home
+// advantage, then every team's attack & defense
+func WriteStrengths(w io.Writer, f Format, p *games.Poisson) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+
+	model := table{
+		headers: []string{"", "Value"},
+		aligns:  []align{alignLeft, alignRight},
+		rows: [][]string{
+			{"Matches", fmt.Sprint(p.Matches)},
+			{"Average goals", fmt.Sprintf("%.2f", p.Average)},
+			{"Home advantage", fmt.Sprintf("%.2f", p.HomeAdvantage)},
+		},
+	}
+
+	teams := table{
+		headers: []string{"Team", "Attack", "Defense"},
+		aligns:  []align{alignLeft, alignRight, alignRight},
+	}
+	for _, s := range p.Strengths() {
+		teams.rows = append(teams.rows, []string{s.Team, fmt.Sprintf("%.2f", s.Attack), fmt.Sprintf("%.2f", s.Defense)})
+	}
+	return writeSections(w, f, []section{{"Model", model}, {"Team strengths", teams}})
+}
+
+// WriteCalibration writes how well the predictions made before each match
+// day matched the results, overall and for each range of chances
+func WriteCalibration(w io.Writer, f Format, c games.Calibration) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+
+	summary := table{
+		headers: []string{"", "Value"},
+		aligns:  []align{alignLeft, alignRight},
+		rows: [][]string{
+			{"Matches", fmt.Sprint(c.Matches)},
+			{"Most likely result", fmt.Sprintf("%v (%v)", c.Correct, percent(float64(c.Correct)/float64(c.Matches)))},
+			{"Brier score", fmt.Sprintf("%.3f", c.Brier)},
+			{"Log loss", fmt.Sprintf("%.3f", c.LogLoss)},
+		},
+	}
+
+	buckets := table{
+		headers: []string{"Chance", "Predictions", "Predicted", "Observed"},
+		aligns:  []align{alignLeft, alignRight, alignRight, alignRight},
+	}
+	for _, b := range c.Buckets {
+		if b.Predictions == 0 {
+			continue
+		}
+		buckets.rows = append(buckets.rows, []string{
+			fmt.Sprintf("%.0f-%.0f%%", 100*b.From, 100*b.To),
+			fmt.Sprint(b.Predictions),
+			percent(b.Predicted),
+			percent(b.Observed),
+		})
+	}
+	return writeSections(w, f, []section{{"Calibration", summary}, {"Calibration by chance", buckets}})
+}
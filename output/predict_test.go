@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+func TestOutput_Predictions(t *testing.T) {
+	preds := []games.Prediction{
+		{
+			Team1: "A", Team2: "B", Expected1: 1.5, Expected2: 0.75,
+			Win1: 0.5, Draw: 0.3, Win2: 0.2,
+			Scores: []games.Scoreline{{Score1: 1, Score2: 0, Probability: 0.15}, {Score1: 1, Score2: 1, Probability: 0.125}, {Score1: 0, Score2: 0, Probability: 0.1}},
+		},
+		{
+			Team1: "Team C", Team2: "D", Neutral: true, Expected1: 1, Expected2: 1,
+			Win1: 0.35, Draw: 0.3, Win2: 0.35,
+			Scores: []games.Scoreline{{Score1: 1, Score2: 1, Probability: 0.135}},
+		},
+	}
+
+	expect := `Predictions
+-----------
+Match                 Expected goals    Win   Draw   Loss  Likely scores
+A v B                      1.50-0.75  50.0%  30.0%  20.0%  1-0 15.0%, 1-1 12.5%
+Team C v D (neutral)       1.00-1.00  35.0%  30.0%  35.0%  1-1 13.5%
+`
+
+	out := &bytes.Buffer{}
+	if err := WritePredictions(out, Text, preds, 2); err != nil {
+		t.Fatalf("unable to write predictions: %v", err)
+	}
+	if out.String() != expect {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+}
@@ -0,0 +1,184 @@
+package output
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+//go:embed site/*.html
+var siteTemplates embed.FS
+
+// sitePage is the data given to each page template
+type sitePage struct {
+	Title  string
+	Season games.Season
+
+	// for match day pages
+	Day        games.Day
+	Prev, Next int
+
+	// for team pages
+	Matches []games.TeamMatch
+
+	// for the index page
+	BumpChart, PointsChart template.HTML
+}
+
+// site generates a static website for a season
+type site struct {
+	season games.Season
+	dir    string
+	pages  map[string]*template.Template
+
+	// teamFiles maps each team to the name of its page
+	teamFiles map[string]string
+}
+
+// WriteSite generates a static website for the season in dir, with an
+// index page with charts, a page for each match day & each team, and a
+// head-to-head matrix. Pages only link to each other, all CSS & images
+// are inline.
+func WriteSite(dir string, s games.Season) error {
+	st := &site{season: s, dir: dir, pages: map[string]*template.Template{}, teamFiles: map[string]string{}}
+
+	used := map[string]bool{}
+	for _, t := range s.Teams {
+		base := fmt.Sprintf("team-%v", slug(t))
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%v-%v", base, i)
+		}
+		used[name] = true
+		st.teamFiles[t] = name + ".html"
+	}
+
+	funcs := template.FuncMap{}
+	for k, v := range TemplateFuncs {
+		funcs[k] = v
+	}
+	funcs["teamPage"] = func(t string) string { return st.teamFiles[t] }
+	funcs["dayPage"] = dayPage
+	funcs["headToHead"] = st.headToHead
+
+	for _, p := range []string{"index", "day", "team", "head_to_head"} {
+		t, err := template.New(p).Funcs(funcs).ParseFS(siteTemplates, "site/layout.html", fmt.Sprintf("site/%v.html", p))
+		if err != nil {
+			return fmt.Errorf("unable to parse site template '%v': %w", p, err)
+		}
+		st.pages[p] = t
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+	return st.write()
+}
+
+// write renders every page of the site
+func (st *site) write() error {
+	index := sitePage{Title: "League", Season: st.season}
+	for _, c := range []struct {
+		chart Chart
+		out   *template.HTML
+	}{{BumpChart, &index.BumpChart}, {PointsChart, &index.PointsChart}} {
+		svg, err := ChartSVG(c.chart, st.season)
+		if err != nil {
+			return err
+		}
+		// the SVG is generated by us and any team names in it are escaped
+		*c.out = template.HTML(svg)
+	}
+
+	if err := st.render("index.html", "index", index); err != nil {
+		return err
+	}
+	if err := st.render("head-to-head.html", "head_to_head", sitePage{Title: "Head to head", Season: st.season}); err != nil {
+		return err
+	}
+
+	for i, d := range st.season.Days {
+		p := sitePage{Title: fmt.Sprintf("Matchday %v", d.Number), Season: st.season, Day: d}
+		if i > 0 {
+			p.Prev = st.season.Days[i-1].Number
+		}
+		if i < len(st.season.Days)-1 {
+			p.Next = st.season.Days[i+1].Number
+		}
+		if err := st.render(dayPage(d.Number), "day", p); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range st.season.Teams {
+		p := sitePage{Title: t, Season: st.season, Matches: st.season.TeamMatches(t)}
+		if err := st.render(st.teamFiles[t], "team", p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render writes a single page using the named page template
+func (st *site) render(file, page string, data sitePage) error {
+	f, err := os.Create(filepath.Join(st.dir, file))
+	if err != nil {
+		return fmt.Errorf("unable to create '%v': %w", file, err)
+	}
+	defer f.Close()
+
+	if err = st.pages[page].ExecuteTemplate(f, "layout", data); err != nil {
+		return fmt.Errorf("unable to render '%v': %w", file, err)
+	}
+	return f.Close()
+}
+
+// headToHead returns the scores of every match between the two teams,
+// from the point of view of the first team
+func (st *site) headToHead(a, b string) []string {
+	out := []string{}
+	for _, m := range st.season.TeamMatches(a) {
+		if m.Opponent != b {
+			continue
+		}
+		s := fmt.Sprintf("%v-%v", m.GoalsFor, m.GoalsAgainst)
+		if m.Result == "" {
+			s = fmt.Sprintf("%v (%v)", s, m.Status)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// dayPage returns the file name of a match day page
+func dayPage(n int) string {
+	return fmt.Sprintf("day-%v.html", n)
+}
+
+// slug turns a team name into something safe to use in a
+// file name, "Aptos F.C." becomes "aptos-f-c"
+func slug(in string) string {
+	var out strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(in) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out.WriteRune(r)
+			dash = false
+		} else if !dash && out.Len() > 0 {
+			out.WriteRune('-')
+			dash = true
+		}
+	}
+
+	s := strings.TrimSuffix(out.String(), "-")
+	if s == "" {
+		s = "team"
+	}
+	return s
+}
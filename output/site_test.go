@@ -0,0 +1,88 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+func TestOutput_Site_Slug(t *testing.T) {
+	tests := []struct {
+		in, expect string
+	}{
+		{"Aptos FC", "aptos-fc"},
+		{"Aptos  F.C.", "aptos-f-c"},
+		{"Brighton & Hove Albion, U23", "brighton-hove-albion-u23"},
+		{"1860 München", "1860-münchen"},
+		{"!!!", "team"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if got := slug(tt.in); got != tt.expect {
+				t.Errorf("wrong slug for '%v', expected '%v' got '%v'", tt.in, tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestOutput_Site_WriteSite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "site")
+	if err := WriteSite(dir, sampleSeason(t)); err != nil {
+		t.Fatalf("unable to write site: %v", err)
+	}
+
+	tests := []struct {
+		file     string
+		contains []string
+	}{
+		{"index.html", []string{"Table after matchday 4", "<svg", "Position after each matchday", `<a href="team-aptos-fc.html">Aptos FC</a>`, `<a href="day-4.html">Matchday 4</a>`}},
+		{"day-1.html", []string{"Matchday 1", `<a href="day-2.html">Matchday 2 &rarr;</a>`, "San Jose Earthquakes"}},
+		{"day-4.html", []string{`<a href="day-3.html">&larr; Matchday 3</a>`}},
+		{"team-aptos-fc.html", []string{`<td class="W">W</td>`, `<a href="team-monterey-united.html">Monterey United</a>`}},
+		{"head-to-head.html", []string{"<td>5-5</td>", `<td class="self"></td>`}},
+	}
+
+	for _, x := range tests {
+		tt := x
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatalf("unable to read page: %v", err)
+			}
+			page := string(data)
+
+			for _, c := range tt.contains {
+				if !strings.Contains(page, c) {
+					t.Errorf("expected page to contain '%v'", c)
+				}
+			}
+			if strings.Contains(page, `src="http`) || strings.Contains(page, `href="http`) {
+				t.Errorf("page should not reference any external assets")
+			}
+		})
+	}
+}
+
+func TestOutput_Site_DuplicateSlugs(t *testing.T) {
+	r := games.NewRanking()
+	if err := r.AddMatch("Aptos FC 1, aptos fc 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteSite(dir, r.Model()); err != nil {
+		t.Fatalf("unable to write site: %v", err)
+	}
+
+	for _, f := range []string{"team-aptos-fc.html", "team-aptos-fc-2.html"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("expected page '%v' to exist: %v", f, err)
+		}
+	}
+}
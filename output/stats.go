@@ -0,0 +1,187 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// WriteStats writes the goal statistics as a titled table for each
+// section, aligned columns for the Text format or one of the markup formats
+func WriteStats(w io.Writer, f Format, st games.Stats) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+
+	return writeSections(w, f, []section{
+		{"League", leagueTable(st.League)},
+		{"Teams", teamsTable(st.Teams)},
+		{"Home & away", homeAwayTable(st.Teams)},
+		{"Team records", teamRecordsTable(st.Teams)},
+		{"Biggest wins", matchesTable(st.League.BiggestWins)},
+		{"Highest-scoring matches", matchesTable(st.League.HighestScoring)},
+	})
+}
+
+// section is a table with a title
+type section struct {
+	title string
+	t     table
+}
+
+// writeSections writes each table under its title, separated by blank
+// lines, as aligned columns for the Text format or one of the markup formats
+func writeSections(w io.Writer, f Format, sections []section) error {
+	for i, s := range sections {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		switch f {
+		case Text:
+			if _, err = fmt.Fprintf(w, "%v\n%v\n", s.title, strings.Repeat("-", len(s.title))); err == nil {
+				err = s.t.writeTerminal(w, func(row, col int, cell string) string { return cell })
+			}
+		case RST:
+			title := escapeMarkup(s.title)
+			if _, err = fmt.Fprintf(w, "%v\n%v\n\n", title, strings.Repeat("=", len(title))); err == nil {
+				err = s.t.writeRST(w)
+			}
+		default:
+			if _, err = fmt.Fprintf(w, "## %v\n\n", escapeMarkup(s.title)); err == nil {
+				err = s.t.writeMarkdown(w, f == GFM)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leagueTable is the league-wide totals
+func leagueTable(l games.LeagueStats) table {
+	perMatch := func(n int) string {
+		if l.Matches == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.2f per match", float64(n)/float64(l.Matches))
+	}
+	percent := func(p float64) string {
+		if l.Matches == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.1f%%", p)
+	}
+
+	return table{
+		headers: []string{"", "Total", ""},
+		aligns:  []align{alignLeft, alignRight, alignLeft},
+		rows: [][]string{
+			{"Matches", fmt.Sprint(l.Matches), ""},
+			{"Goals", fmt.Sprint(l.Goals), perMatch(l.Goals)},
+			{"Home goals", fmt.Sprint(l.HomeGoals), perMatch(l.HomeGoals)},
+			{"Away goals", fmt.Sprint(l.AwayGoals), perMatch(l.AwayGoals)},
+			{"Home wins", fmt.Sprint(l.HomeWins), percent(l.HomeWinPercent())},
+			{"Away wins", fmt.Sprint(l.AwayWins), percent(l.AwayWinPercent())},
+			{"Draws", fmt.Sprint(l.Draws), percent(l.DrawPercent())},
+			{"Clean sheets", fmt.Sprint(l.CleanSheets), ""},
+			{"Failed to score", fmt.Sprint(l.FailedToScore), ""},
+		},
+	}
+}
+
+// teamsTable is each team's overall record
+func teamsTable(teams []games.TeamStats) table {
+	t := table{
+		headers: []string{"Team", "P", "W", "D", "L", "GF", "GA", "GD", "GF/P", "CS", "FTS", "D%"},
+		aligns:  []align{alignLeft},
+	}
+	for range t.headers[1:] {
+		t.aligns = append(t.aligns, alignRight)
+	}
+
+	for _, ts := range teams {
+		t.rows = append(t.rows, []string{
+			ts.Team,
+			fmt.Sprint(ts.Played), fmt.Sprint(ts.Won), fmt.Sprint(ts.Drawn), fmt.Sprint(ts.Lost),
+			fmt.Sprint(ts.GoalsFor), fmt.Sprint(ts.GoalsAgainst), signed(ts.GoalDifference()),
+			fmt.Sprintf("%.2f", ts.GoalsPerMatch()),
+			fmt.Sprint(ts.CleanSheets), fmt.Sprint(ts.FailedToScore),
+			fmt.Sprintf("%.1f", ts.DrawPercent()),
+		})
+	}
+	return t
+}
+
+// signed writes the number with a plus sign in front if it's positive
+func signed(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%v", n)
+	}
+	return fmt.Sprint(n)
+}
+
+// homeAwayTable is each team's record at home & away, as won-drawn-lost
+// & goals for-against
+func homeAwayTable(teams []games.TeamStats) table {
+	t := table{
+		headers: []string{"Team", "Home P", "Home W-D-L", "Home goals", "Away P", "Away W-D-L", "Away goals"},
+		aligns:  []align{alignLeft, alignRight, alignRight, alignRight, alignRight, alignRight, alignRight},
+	}
+
+	for _, ts := range teams {
+		row := []string{ts.Team}
+		for _, r := range []games.Record{ts.Home, ts.Away} {
+			row = append(row,
+				fmt.Sprint(r.Played),
+				fmt.Sprintf("%v-%v-%v", r.Won, r.Drawn, r.Lost),
+				fmt.Sprintf("%v-%v", r.GoalsFor, r.GoalsAgainst),
+			)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// teamRecordsTable is each team's biggest win & highest-scoring match
+func teamRecordsTable(teams []games.TeamStats) table {
+	t := table{
+		headers: []string{"Team", "Biggest win", "Highest-scoring match"},
+		aligns:  []align{alignLeft, alignLeft, alignLeft},
+	}
+
+	for _, ts := range teams {
+		t.rows = append(t.rows, []string{ts.Team, teamResult(ts.Team, ts.BiggestWin), teamResult(ts.Team, ts.HighestScoring)})
+	}
+	return t
+}
+
+// teamResult describes the match from the team's point of view, like
+// "4-0 v Team B, day 3"
+func teamResult(team string, dm *games.DayMatch) string {
+	if dm == nil {
+		return ""
+	}
+	if dm.Team1 == team {
+		return fmt.Sprintf("%v-%v v %v, day %v", dm.Score1, dm.Score2, dm.Team2, dm.Day)
+	}
+	return fmt.Sprintf("%v-%v v %v, day %v", dm.Score2, dm.Score1, dm.Team1, dm.Day)
+}
+
+// matchesTable is a list of matches, with the home team first
+func matchesTable(ms []games.DayMatch) table {
+	t := table{
+		headers: []string{"Day", "Match"},
+		aligns:  []align{alignRight, alignLeft},
+	}
+	for _, dm := range ms {
+		t.rows = append(t.rows, []string{fmt.Sprint(dm.Day), fmt.Sprintf("%v %v, %v %v", dm.Team1, dm.Score1, dm.Team2, dm.Score2)})
+	}
+	return t
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+func TestOutput_Stats(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"A 2, B 0", "B 1, A 1"} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	st := r.Model().Stats(1)
+
+	tests := []struct {
+		format Format
+		expect string
+	}{
+		{Text, `League
+------
+                 Total
+Matches              2
+Goals                4  2.00 per match
+Home goals           3  1.50 per match
+Away goals           1  0.50 per match
+Home wins            1  50.0%
+Away wins            0  0.0%
+Draws                1  50.0%
+Clean sheets         1
+Failed to score      1
+
+Teams
+-----
+Team  P  W  D  L  GF  GA  GD  GF/P  CS  FTS    D%
+A     2  1  1  0   3   1  +2  1.50   1    0  50.0
+B     2  0  1  1   1   3  -2  0.50   0    1  50.0
+
+Home & away
+-----------
+Team  Home P  Home W-D-L  Home goals  Away P  Away W-D-L  Away goals
+A          1       1-0-0         2-0       1       0-1-0         1-1
+B          1       0-1-0         1-1       1       0-0-1         0-2
+
+Team records
+------------
+Team  Biggest win     Highest-scoring match
+A     2-0 v B, day 1  2-0 v B, day 1
+B                     0-2 v A, day 1
+
+Biggest wins
+------------
+Day  Match
+  1  A 2, B 0
+
+Highest-scoring matches
+-----------------------
+Day  Match
+  1  A 2, B 0
+`},
+		{GFM, `## League
+
+|                 | Total |                |
+| :-------------- | ----: | :------------- |
+| Matches         |     2 |                |
+| Goals           |     4 | 2.00 per match |
+| Home goals      |     3 | 1.50 per match |
+| Away goals      |     1 | 0.50 per match |
+| Home wins       |     1 | 50.0%          |
+| Away wins       |     0 | 0.0%           |
+| Draws           |     1 | 50.0%          |
+| Clean sheets    |     1 |                |
+| Failed to score |     1 |                |
+
+## Teams
+
+| Team |   P |   W |   D |   L |  GF |  GA |  GD | GF/P |  CS | FTS |   D% |
+| :--- | --: | --: | --: | --: | --: | --: | --: | ---: | --: | --: | ---: |
+| A    |   2 |   1 |   1 |   0 |   3 |   1 |  +2 | 1.50 |   1 |   0 | 50.0 |
+| B    |   2 |   0 |   1 |   1 |   1 |   3 |  -2 | 0.50 |   0 |   1 | 50.0 |
+
+## Home & away
+
+| Team | Home P | Home W-D-L | Home goals | Away P | Away W-D-L | Away goals |
+| :--- | -----: | ---------: | ---------: | -----: | ---------: | ---------: |
+| A    |      1 |      1-0-0 |        2-0 |      1 |      0-1-0 |        1-1 |
+| B    |      1 |      0-1-0 |        1-1 |      1 |      0-0-1 |        0-2 |
+
+## Team records
+
+| Team | Biggest win    | Highest-scoring match |
+| :--- | :------------- | :-------------------- |
+| A    | 2-0 v B, day 1 | 2-0 v B, day 1        |
+| B    |                | 0-2 v A, day 1        |
+
+## Biggest wins
+
+| Day | Match    |
+| --: | :------- |
+|   1 | A 2, B 0 |
+
+## Highest-scoring matches
+
+| Day | Match    |
+| --: | :------- |
+|   1 | A 2, B 0 |
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := WriteStats(out, tt.format, st); err != nil {
+				t.Fatalf("unable to write stats: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+
+	if err := WriteStats(&bytes.Buffer{}, Format("html"), st); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
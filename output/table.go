@@ -0,0 +1,140 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// align is how the contents of a column are lined up
+type align int
+
+const (
+	alignLeft align = iota
+	alignRight
+)
+
+// table is a simple grid of text, rendered by the various
+// table formats
+type table struct {
+	headers []string
+	aligns  []align
+	rows    [][]string
+}
+
+// widths returns how wide each column needs to be to fit every
+// cell, after the cells have been escaped
+func (t table) widths(escape func(string) string) []int {
+	w := make([]int, len(t.headers))
+	for i, h := range t.headers {
+		w[i] = utf8.RuneCountInString(escape(h))
+	}
+	for _, r := range t.rows {
+		for i, c := range r {
+			if l := utf8.RuneCountInString(escape(c)); l > w[i] {
+				w[i] = l
+			}
+		}
+	}
+	return w
+}
+
+// pad pads the string out to the width using the alignment
+func pad(s string, width int, a align) string {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	if a == alignRight {
+		return strings.Repeat(" ", n) + s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// escapeMarkup puts a backslash in front of characters that would
+// otherwise be treated as markup in Markdown or reStructuredText,
+// including the pipes that separate table cells
+func escapeMarkup(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\|*_`[]", r) {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// writeMarkdown writes the table as a Markdown pipe table. GitHub
+// flavored tables get outer pipes and alignment markers, plain pipe
+// tables have neither.
+func (t table) writeMarkdown(w io.Writer, gfm bool) error {
+	widths := t.widths(escapeMarkup)
+	sep := make([]string, len(widths))
+	for i := range widths {
+		if !gfm {
+			sep[i] = strings.Repeat("-", widths[i])
+			continue
+		}
+
+		// alignment markers need at least three characters, ":--"
+		if widths[i] < 3 {
+			widths[i] = 3
+		}
+		if t.aligns[i] == alignRight {
+			sep[i] = strings.Repeat("-", widths[i]-1) + ":"
+		} else {
+			sep[i] = ":" + strings.Repeat("-", widths[i]-1)
+		}
+	}
+
+	line := func(cells []string) string {
+		if gfm {
+			return fmt.Sprintf("| %v |\n", strings.Join(cells, " | "))
+		}
+		return fmt.Sprintf("%v\n", strings.TrimRight(strings.Join(cells, " | "), " "))
+	}
+	row := func(cells []string) string {
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = pad(escapeMarkup(c), widths[i], t.aligns[i])
+		}
+		return line(out)
+	}
+
+	out := row(t.headers) + line(sep)
+	for _, r := range t.rows {
+		out += row(r)
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// writeRST writes the table as a reStructuredText grid table
+func (t table) writeRST(w io.Writer) error {
+	widths := t.widths(escapeMarkup)
+	border := func(c string) string {
+		out := []string{}
+		for _, wd := range widths {
+			out = append(out, strings.Repeat(c, wd+2))
+		}
+		return fmt.Sprintf("+%v+\n", strings.Join(out, "+"))
+	}
+	row := func(cells []string) string {
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = pad(escapeMarkup(c), widths[i], t.aligns[i])
+		}
+		return fmt.Sprintf("| %v |\n", strings.Join(out, " | "))
+	}
+
+	out := border("-") + row(t.headers) + border("=")
+	for _, r := range t.rows {
+		out += row(r) + border("-")
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// Template is a parsed text/template or html/template
+type Template interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// TemplateFuncs are the extra functions available to templates
+var TemplateFuncs = map[string]interface{}{
+	// plural returns one if n is 1 or -1, otherwise many
+	"plural": plural,
+
+	// pts returns "pt" or "pts" for the number of points, same as the text output
+	"pts": func(n int) string { return plural(n, "pt", "pts") },
+
+	// add adds two numbers, handy for things like "{{add $i 1}}"
+	"add": func(a, b int) int { return a + b },
+
+	// join joins strings with a separator
+	"join": strings.Join,
+}
+
+// plural returns one if n is 1 or -1, otherwise many
+func plural(n int, one, many string) string {
+	if n == 1 || n == -1 {
+		return one
+	}
+	return many
+}
+
+// LoadTemplate parses the template file at path. Files ending in .html or
+// .htm are parsed with html/template, so that team names are escaped, any
+// other file is parsed with text/template.
+func LoadTemplate(path string) (Template, error) {
+	name := filepath.Base(path)
+
+	var t Template
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		t, err = htmltemplate.New(name).Funcs(htmltemplate.FuncMap(TemplateFuncs)).ParseFiles(path)
+	default:
+		t, err = template.New(name).Funcs(template.FuncMap(TemplateFuncs)).ParseFiles(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template: %w", err)
+	}
+	return t, nil
+}
+
+// RenderTemplate renders the season using the template file at path
+func RenderTemplate(w io.Writer, path string, s games.Season) error {
+	t, err := LoadTemplate(path)
+	if err != nil {
+		return err
+	}
+
+	if err = t.Execute(w, s); err != nil {
+		return fmt.Errorf("unable to render template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// sampleSeason reads the sample input from the testdata directory
+func sampleSeason(t *testing.T) games.Season {
+	data, err := ioutil.ReadFile("../testdata/sample-input.txt")
+	if err != nil {
+		t.Fatalf("unable to read sample input: %v", err)
+	}
+
+	r := games.NewRanking()
+	for _, l := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if err = r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+	return r.Model()
+}
+
+func TestOutput_Template_MatchesResults(t *testing.T) {
+	expect, err := ioutil.ReadFile("../testdata/expected-output.txt")
+	if err != nil {
+		t.Fatalf("unable to read expected output: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err = RenderTemplate(out, "../testdata/templates/results.tmpl", sampleSeason(t)); err != nil {
+		t.Fatalf("unable to render template: %v", err)
+	}
+
+	if out.String() != string(expect) {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(string(expect), out.String()))
+	}
+}
+
+func TestOutput_Template_Types(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := `{{range .Teams}}{{.}};{{end}}`
+
+	r := games.NewRanking()
+	if err := r.AddMatch("<b>Tom & Jerry</b> 1, B 0"); err != nil {
+		t.Fatalf("unable to add match: %v", err)
+	}
+
+	tests := []struct {
+		file   string
+		expect string
+	}{
+		{"teams.txt", "<b>Tom & Jerry</b>;B;"},
+		{"teams.html", "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;;B;"},
+	}
+
+	for _, x := range tests {
+		tt := x
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+				t.Fatalf("unable to write template: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := RenderTemplate(out, path, r.Model()); err != nil {
+				t.Fatalf("unable to render template: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output, expected '%v' got '%v'", tt.expect, out.String())
+			}
+		})
+	}
+}
+
+func TestOutput_Template_Errors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.tmpl")
+	if err := os.WriteFile(bad, []byte("{{range .Days}"), 0644); err != nil {
+		t.Fatalf("unable to write template: %v", err)
+	}
+	missing := filepath.Join(dir, "missing.tmpl")
+	if err := os.WriteFile(missing, []byte("{{.NoSuchField}}"), 0644); err != nil {
+		t.Fatalf("unable to write template: %v", err)
+	}
+
+	for _, path := range []string{bad, missing, filepath.Join(dir, "nope.tmpl")} {
+		if err := RenderTemplate(&bytes.Buffer{}, path, games.Season{}); err == nil {
+			t.Errorf("expected error rendering '%v', got nothing", path)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// MinTeamWidth is the narrowest the team column gets when fitting
+// a table to the terminal, names longer than this are cut short
+const MinTeamWidth = 8
+
+// minNoteWidth is the narrowest the note column gets before it's
+// dropped from the table entirely
+const minNoteWidth = 12
+
+// columnGap is the space between columns in a terminal table
+const columnGap = "  "
+
+// TerminalOptions controls how WriteTerminal draws the standings
+type TerminalOptions struct {
+	// Color turns on ANSI colors
+	Color bool
+
+	// Width is the width of the terminal in columns, tables wider than
+	// this are squeezed to fit. Zero means there's no limit.
+	Width int
+
+	// Promotion & Relegation are how many teams at the top & bottom
+	// of the table are highlighted
+	Promotion  int
+	Relegation int
+}
+
+// movement arrows shown next to a team's position
+const (
+	arrowUp   = "▲"
+	arrowDown = "▼"
+	arrowSame = "="
+)
+
+// WriteTerminal writes the full standings for each match day as aligned
+// tables, with arrows showing how each team's position changed since the
+// previous match day
+func WriteTerminal(w io.Writer, loc *locale.Locale, s games.Season, opts TerminalOptions) error {
+	return writeSeason(NewTerminalWriter(w, loc, opts), s)
+}
+
+// WriteStandings writes a single table of standings for the terminal, with
+// arrows showing how each team's position changed from the previous standings
+func WriteStandings(w io.Writer, loc *locale.Locale, standings, previous []games.Standing, opts TerminalOptions) error {
+	au := aurora.NewAurora(opts.Color)
+
+	prev := map[string]int{}
+	for _, st := range previous {
+		prev[st.Team] = st.Position
+	}
+
+	t := standingsTable(loc, standings)
+	t.headers = append(t.headers[:1], append([]string{""}, t.headers[1:]...)...)
+	t.aligns = append(t.aligns[:1], append([]align{alignLeft}, t.aligns[1:]...)...)
+	for j, st := range standings {
+		r := t.rows[j]
+		t.rows[j] = append(r[:1], append([]string{movement(prev, st)}, r[1:]...)...)
+	}
+
+	zone := func(row int) func(interface{}) aurora.Value {
+		switch {
+		case row < opts.Promotion:
+			return au.Green
+		case row >= len(standings)-opts.Relegation:
+			return au.Red
+		}
+		return nil
+	}
+
+	style := func(row, col int, cell string) string {
+		if row < 0 {
+			return au.Bold(cell).String()
+		}
+		if col == 1 {
+			switch {
+			case strings.HasPrefix(cell, arrowUp):
+				return au.Green(cell).String()
+			case strings.HasPrefix(cell, arrowDown):
+				return au.Red(cell).String()
+			}
+			return au.Faint(cell).String()
+		}
+		if z := zone(row); z != nil && col <= 2 {
+			return z(cell).String()
+		}
+		return cell
+	}
+
+	note := -1
+	if last := len(t.headers) - 1; t.headers[last] == loc.Message(locale.MsgNote) {
+		note = last
+	}
+	return t.fit(opts.Width, 2, note).writeTerminal(w, style)
+}
+
+// movement returns the arrow & number of places a team moved since
+// the previous day, empty on the first day or for a new team
+func movement(prev map[string]int, st games.Standing) string {
+	p, ok := prev[st.Team]
+	switch {
+	case !ok:
+		return ""
+	case p > st.Position:
+		return fmt.Sprintf("%v%v", arrowUp, p-st.Position)
+	case p < st.Position:
+		return fmt.Sprintf("%v%v", arrowDown, st.Position-p)
+	}
+	return arrowSame
+}
+
+// truncate cuts the string down to the width, ending it with
+// an ellipsis if anything was cut
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:width])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+// fit returns a copy of the table squeezed to fit in the width. The
+// note column is shortened first and dropped if it gets too narrow,
+// then the team column is shortened down to MinTeamWidth. Use -1 for
+// the note column if the table doesn't have one.
+func (t table) fit(width, team, note int) table {
+	if width <= 0 || t.width() <= width {
+		return t
+	}
+
+	if note >= 0 {
+		nw := t.widths(func(s string) string { return s })[note] - (t.width() - width)
+		if nw >= minNoteWidth {
+			return t.truncateColumn(note, nw)
+		}
+
+		out := table{headers: t.headers[:note], aligns: t.aligns[:note]}
+		for _, r := range t.rows {
+			out.rows = append(out.rows, r[:note])
+		}
+		if out.width() <= width {
+			return out
+		}
+		t = out
+	}
+
+	tw := t.widths(func(s string) string { return s })[team] - (t.width() - width)
+	if tw < MinTeamWidth {
+		tw = MinTeamWidth
+	}
+	return t.truncateColumn(team, tw)
+}
+
+// truncateColumn returns a copy of the table with every cell in
+// the column cut down to the width
+func (t table) truncateColumn(col, width int) table {
+	out := table{
+		headers: append([]string{}, t.headers...),
+		aligns:  t.aligns,
+	}
+	out.headers[col] = truncate(out.headers[col], width)
+	for _, r := range t.rows {
+		nr := append([]string{}, r...)
+		nr[col] = truncate(nr[col], width)
+		out.rows = append(out.rows, nr)
+	}
+	return out
+}
+
+// width is how many columns wide the table is when written
+// to the terminal
+func (t table) width() int {
+	total := 0
+	for _, w := range t.widths(func(s string) string { return s }) {
+		total += w
+	}
+	return total + len(columnGap)*(len(t.headers)-1)
+}
+
+// writeTerminal writes the table as aligned columns. The style function
+// is called with every padded cell so it can add colors, the row is -1
+// for the header.
+func (t table) writeTerminal(w io.Writer, style func(row, col int, cell string) string) error {
+	widths := t.widths(func(s string) string { return s })
+	line := func(row int, cells []string) string {
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = style(row, i, pad(c, widths[i], t.aligns[i]))
+		}
+		return fmt.Sprintf("%v\n", strings.TrimRight(strings.Join(out, columnGap), " "))
+	}
+
+	out := line(-1, t.headers)
+	for i, r := range t.rows {
+		out += line(i, r)
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
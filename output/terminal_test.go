@@ -0,0 +1,134 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+func TestOutput_Terminal_WriteTerminal(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"Aptos 1, Boulder Creek 0", "Capitola 0, Davenport 0", "Boulder Creek 2, Capitola 0", "Aptos 0, Davenport 1", `!deduct "Aptos" 1 "paperwork filed late"`} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+
+	en, err := locale.Get("en")
+	if err != nil {
+		t.Fatalf("unable to get locale: %v", err)
+	}
+
+	tests := []struct {
+		opts   TerminalOptions
+		expect string
+	}{
+		{TerminalOptions{}, `Matchday 1
+Pos    Team           Pts
+  1    Aptos            3
+  2    Capitola         1
+  3    Davenport        1
+  4    Boulder Creek    0
+
+Matchday 2
+Pos      Team           Pts  Note
+  1  ▲2  Davenport        4
+  2  ▲2  Boulder Creek    3
+  3  ▼2  Aptos            2  -1 pt: paperwork filed late
+  4  ▼2  Capitola         1
+`},
+		{TerminalOptions{Width: 50}, `Matchday 1
+Pos    Team           Pts
+  1    Aptos            3
+  2    Capitola         1
+  3    Davenport        1
+  4    Boulder Creek    0
+
+Matchday 2
+Pos      Team           Pts  Note
+  1  ▲2  Davenport        4
+  2  ▲2  Boulder Creek    3
+  3  ▼2  Aptos            2  -1 pt: paperwork fil…
+  4  ▼2  Capitola         1
+`},
+		{TerminalOptions{Width: 24}, `Matchday 1
+Pos    Team          Pts
+  1    Aptos           3
+  2    Capitola        1
+  3    Davenport       1
+  4    Boulder Cre…    0
+
+Matchday 2
+Pos      Team        Pts
+  1  ▲2  Davenport     4
+  2  ▲2  Boulder C…    3
+  3  ▼2  Aptos         2
+  4  ▼2  Capitola      1
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := WriteTerminal(out, en, r.Model(), tt.opts); err != nil {
+				t.Fatalf("unable to write table: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
+
+func TestOutput_Terminal_Color(t *testing.T) {
+	en, err := locale.Get("en")
+	if err != nil {
+		t.Fatalf("unable to get locale: %v", err)
+	}
+
+	opts := TerminalOptions{Color: true, Promotion: 1, Relegation: 1}
+	out := &bytes.Buffer{}
+	if err := WriteTerminal(out, en, sampleSeason(t), opts); err != nil {
+		t.Fatalf("unable to write table: %v", err)
+	}
+
+	expect := []string{
+		"\x1b[32m  1\x1b[0m",                  // promotion zone
+		"\x1b[31mSan Jose Earthquakes\x1b[0m", // relegation zone
+		"\x1b[32m▲2\x1b[0m",                   // moved up
+		"\x1b[31m▼1\x1b[0m",                   // moved down
+	}
+	for _, e := range expect {
+		if !strings.Contains(out.String(), e) {
+			t.Errorf("expected output to contain %q", e)
+		}
+	}
+}
+
+func TestOutput_Terminal_Truncate(t *testing.T) {
+	tests := []struct {
+		in     string
+		width  int
+		expect string
+	}{
+		{"Aptos", 8, "Aptos"},
+		{"Santa Cruz Slugs", 8, "Santa C…"},
+		{"Équipe", 3, "Éq…"},
+		{"Aptos", 1, "A"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if got := truncate(tt.in, tt.width); got != tt.expect {
+				t.Errorf("wrong truncation, expected '%v' got '%v'", tt.expect, got)
+			}
+		})
+	}
+}
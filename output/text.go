@@ -0,0 +1,48 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// WriteText writes the top teams for each match day in the plain text
+// format, translated for the locale. The "en" locale gives the same
+// output as Ranking.Results.
+func WriteText(w io.Writer, loc *locale.Locale, s games.Season) error {
+	return writeSeason(NewTextWriter(w, loc), s)
+}
+
+// teamLabel is the team's name with its race marker in front, the way
+// newspapers print them, like "c-Team A"
+func teamLabel(st games.Standing) string {
+	if m := st.Race.Marker(); m != "" {
+		return m + "-" + st.Team
+	}
+	return st.Team
+}
+
+// standingNote is games.Standing.Note translated for the locale
+func standingNote(loc *locale.Locale, st games.Standing) string {
+	notes := []string{}
+	for _, a := range st.Adjustments {
+		n := fmt.Sprintf("%v %v", loc.SignedNumber(a.Points), loc.PluralMessage(locale.MsgPoints, a.Points))
+		if a.Reason != "" {
+			n = fmt.Sprintf("%v: %v", n, a.Reason)
+		}
+		notes = append(notes, n)
+	}
+
+	if st.Forfeit {
+		n := loc.Message(locale.MsgForfeit, games.ForfeitScore)
+		if st.ForfeitReason != "" {
+			n = fmt.Sprintf("%v: %v", n, st.ForfeitReason)
+		}
+		notes = append(notes, n)
+	}
+
+	return strings.Join(notes, "; ")
+}
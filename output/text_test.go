@@ -0,0 +1,185 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+func TestOutput_Text_MatchesResults(t *testing.T) {
+	expect, err := ioutil.ReadFile("../testdata/expected-output.txt")
+	if err != nil {
+		t.Fatalf("unable to read expected output: %v", err)
+	}
+
+	en, err := locale.Get("en")
+	if err != nil {
+		t.Fatalf("unable to get locale: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err = WriteText(out, en, sampleSeason(t)); err != nil {
+		t.Fatalf("unable to write text: %v", err)
+	}
+
+	if out.String() != string(expect) {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(string(expect), out.String()))
+	}
+}
+
+func TestOutput_Text_Locales(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"A 1, B 0", "C 0, D 0", `!forfeit "A" "C" "no show"`, "B 0, D 1", `!deduct "D" 3 "late"`} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+
+	tests := []struct {
+		tag    string
+		expect string
+	}{
+		{"en", `Matchday 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Matchday 2
+A, 6 pts (awarded 3-0 by forfeit: no show)
+C, 1 pt
+D, 1 pt (-3 pts: late)
+`},
+		{"es", `Jornada 1
+A, 3 ptos
+C, 1 pto
+D, 1 pto
+
+Jornada 2
+A, 6 ptos (ganado 3-0 por incomparecencia: no show)
+C, 1 pto
+D, 1 pto (-3 ptos: late)
+`},
+		{"fr", `Journée 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Journée 2
+A, 6 pts (victoire 3-0 par forfait: no show)
+C, 1 pt
+D, 1 pt (-3 pts: late)
+`},
+		{"pt", `Rodada 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Rodada 2
+A, 6 pts (vitória por 3-0 por W.O.: no show)
+C, 1 pt
+D, 1 pt (-3 pts: late)
+`},
+		{"pt-PT", `Jornada 1
+A, 3 pts
+C, 1 pt
+D, 1 pt
+
+Jornada 2
+A, 6 pts (vitória por 3-0 por falta de comparência: no show)
+C, 1 pt
+D, 1 pt (-3 pts: late)
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_%v", i, tt.tag), func(t *testing.T) {
+			loc, err := locale.Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := WriteText(out, loc, r.Model()); err != nil {
+				t.Fatalf("unable to write text: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
+
+func TestOutput_Text_Schedule(t *testing.T) {
+	fr, _ := locale.Get("fr")
+	day := func(n int, st []games.Standing) games.Day {
+		return games.Day{Number: n, Standings: st}
+	}
+
+	tests := []struct {
+		days   []games.Day
+		expect string
+	}{
+		{
+			// only the last day's strength of schedule is written
+			[]games.Day{
+				day(1, []games.Standing{
+					{Position: 1, Team: "A", Points: 3, Schedule: &games.Schedule{Played: 1}},
+				}),
+				day(2, []games.Standing{
+					{Position: 1, Team: "A", Points: 3, Schedule: &games.Schedule{Played: 2, Past: 1.4, Remaining: 1, Future: 4}},
+					{Position: 2, Team: "B", Points: -1, Adjustments: []games.Adjustment{{Points: -1, Reason: "late"}}, Schedule: &games.Schedule{Played: 2, Past: 3}},
+					{Position: 3, Team: "C", Points: 0, Schedule: &games.Schedule{}},
+					{Position: 4, Team: "D", Points: 0, Schedule: &games.Schedule{Played: 1, Past: 2.5}},
+				}),
+			},
+			`Journée 1
+A, 3 pts
+
+Journée 2
+A, 3 pts
+B, -1 pt (-1 pt: late)
+C, 0 pt
+
+Classement final
+A, 3 pts, Diff. 1,4, Diff. rest. 4,0
+B, -1 pt, Diff. 3,0 (-1 pt: late)
+C, 0 pt
+D, 0 pt, Diff. 2,5
+`,
+		},
+		{
+			// no final table without it
+			[]games.Day{
+				day(1, []games.Standing{
+					{Position: 1, Team: "A", Points: 3, Schedule: &games.Schedule{Played: 1}},
+				}),
+				day(2, []games.Standing{{Position: 1, Team: "A", Points: 3}}),
+			},
+			`Journée 1
+A, 3 pts
+
+Journée 2
+A, 3 pts
+`,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := WriteText(out, fr, games.Season{Days: tt.days}); err != nil {
+				t.Fatalf("unable to write text: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+// venueTable is one of the home or away tables, with its title
+type venueTable struct {
+	title     string
+	standings []games.Standing
+}
+
+// venueTables returns the home & away tables for the season
+func venueTables(loc *locale.Locale, s games.Season) []venueTable {
+	return []venueTable{
+		{loc.Message(locale.MsgHomeTable), s.HomeTable()},
+		{loc.Message(locale.MsgAwayTable), s.AwayTable()},
+	}
+}
+
+// WriteVenueTables writes the home & away tables for the season in the
+// given format, see games.Season.HomeTable. They're meant to follow the
+// match day output, so each table starts with a blank line. The Text
+// format lists every team the same way as the match days.
+func WriteVenueTables(w io.Writer, f Format, loc *locale.Locale, s games.Season) error {
+	if f != Text {
+		dw, err := NewMarkupWriter(w, f, loc)
+		if err != nil {
+			return err
+		}
+		mw := dw.(*markupWriter)
+		mw.written = true
+		for _, vt := range venueTables(loc, s) {
+			if err = mw.section(vt.title, vt.standings); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, vt := range venueTables(loc, s) {
+		if _, err := fmt.Fprintf(w, "\n%v\n", vt.title); err != nil {
+			return err
+		}
+		for _, st := range vt.standings {
+			_, err := fmt.Fprintf(w, "%v, %v %v\n", st.Team, loc.Number(st.Points), loc.PluralMessage(locale.MsgPoints, st.Points))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTerminalVenueTables is WriteVenueTables for terminal tables. There's
+// no movement to show and the promotion & relegation places only apply to
+// the overall table, so neither are highlighted.
+func WriteTerminalVenueTables(w io.Writer, loc *locale.Locale, s games.Season, opts TerminalOptions) error {
+	au := aurora.NewAurora(opts.Color)
+	opts.Promotion, opts.Relegation = 0, 0
+
+	for _, vt := range venueTables(loc, s) {
+		if _, err := fmt.Fprintf(w, "\n%v\n", au.Bold(vt.title)); err != nil {
+			return err
+		}
+		if err := WriteStandings(w, loc, vt.standings, nil, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
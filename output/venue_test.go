@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+)
+
+func TestOutput_VenueTables(t *testing.T) {
+	r := games.NewRanking()
+	for _, l := range []string{"A 1, B 0", "C 0, D 0 @ Neutral", "B 2, D 1", "C 1, A 1"} {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add match '%v': %v", l, err)
+		}
+	}
+
+	tests := []struct {
+		f      Format
+		tag    string
+		expect string
+	}{
+		{Text, "en", `
+Home Table
+A, 3 pts
+B, 3 pts
+C, 1 pt
+D, 0 pts
+
+Away Table
+A, 1 pt
+B, 0 pts
+C, 0 pts
+D, 0 pts
+`},
+		{GFM, "es", `
+## Clasificación como local
+
+| Pos | Equipo | Ptos |
+| --: | :----- | ---: |
+|   1 | A      |    3 |
+|   2 | B      |    3 |
+|   3 | C      |    1 |
+|   4 | D      |    0 |
+
+## Clasificación como visitante
+
+| Pos | Equipo | Ptos |
+| --: | :----- | ---: |
+|   1 | A      |    1 |
+|   2 | B      |    0 |
+|   3 | C      |    0 |
+|   4 | D      |    0 |
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			loc, err := locale.Get(tt.tag)
+			if err != nil {
+				t.Fatalf("unable to get locale: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err = WriteVenueTables(out, tt.f, loc, r.Model()); err != nil {
+				t.Fatalf("unable to write venue tables: %v", err)
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
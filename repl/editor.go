@@ -0,0 +1,209 @@
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// ErrInterrupted is returned by ReadLine when ctrl-c is pressed
+var ErrInterrupted = errors.New("interrupted")
+
+// MaxHistory is how many lines the editor remembers
+const MaxHistory = 500
+
+// Editor reads lines from the terminal with basic line editing: moving
+// the cursor, deleting, and going back through earlier lines with the
+// up & down arrows.
+type Editor struct {
+	history []string
+
+	// the line being edited, and where the cursor is in it
+	buf []rune
+	pos int
+
+	// hist is the history entry being shown, len(history) is the new
+	// line, which is kept in saved while looking through the history
+	hist  int
+	saved []rune
+
+	// pending are keys that were read but not handled yet, such as
+	// the lines after the first when several lines are pasted at once
+	pending []term.Key
+}
+
+// NewEditor creates a line editor with no history
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+// AddHistory adds a line to the history, blank lines and repeats
+// of the previous line are skipped
+func (e *Editor) AddHistory(line string) {
+	if line == "" {
+		return
+	}
+	if l := len(e.history); l > 0 && e.history[l-1] == line {
+		return
+	}
+	e.history = append(e.history, line)
+	if len(e.history) > MaxHistory {
+		e.history = e.history[len(e.history)-MaxHistory:]
+	}
+}
+
+// reset starts a new empty line
+func (e *Editor) reset() {
+	e.buf = []rune{}
+	e.pos = 0
+	e.hist = len(e.history)
+	e.saved = nil
+}
+
+// HandleKey updates the line for a key press. When enter is pressed the
+// line is returned and done is true. Ctrl-D on an empty line returns
+// io.EOF, ctrl-c returns ErrInterrupted.
+func (e *Editor) HandleKey(k term.Key) (line string, done bool, err error) {
+	switch k.Code {
+	case term.KeyRune:
+		e.buf = append(e.buf[:e.pos], append([]rune{k.Rune}, e.buf[e.pos:]...)...)
+		e.pos++
+	case term.KeyTab:
+		return e.HandleKey(term.Key{Code: term.KeyRune, Rune: ' '})
+	case term.KeyEnter:
+		return string(e.buf), true, nil
+	case term.KeyCtrlC:
+		return "", true, ErrInterrupted
+	case term.KeyCtrlD:
+		if len(e.buf) == 0 {
+			return "", true, io.EOF
+		}
+		e.delete(e.pos)
+	case term.KeyBackspace:
+		if e.pos > 0 {
+			e.pos--
+			e.delete(e.pos)
+		}
+	case term.KeyDelete:
+		e.delete(e.pos)
+	case term.KeyLeft:
+		if e.pos > 0 {
+			e.pos--
+		}
+	case term.KeyRight:
+		if e.pos < len(e.buf) {
+			e.pos++
+		}
+	case term.KeyHome, term.KeyCtrlA:
+		e.pos = 0
+	case term.KeyEnd, term.KeyCtrlE:
+		e.pos = len(e.buf)
+	case term.KeyCtrlU:
+		e.buf = e.buf[e.pos:]
+		e.pos = 0
+	case term.KeyUp:
+		e.showHistory(e.hist - 1)
+	case term.KeyDown:
+		e.showHistory(e.hist + 1)
+	}
+	return "", false, nil
+}
+
+// delete removes the character at i, if there is one
+func (e *Editor) delete(i int) {
+	if i < len(e.buf) {
+		e.buf = append(e.buf[:i], e.buf[i+1:]...)
+	}
+}
+
+// showHistory replaces the line with history entry h, the entry past
+// the end of the history is the line that was being typed
+func (e *Editor) showHistory(h int) {
+	if h < 0 || h > len(e.history) || h == e.hist {
+		return
+	}
+	if e.hist == len(e.history) {
+		e.saved = e.buf
+	}
+
+	e.hist = h
+	if h == len(e.history) {
+		e.buf = e.saved
+	} else {
+		e.buf = []rune(e.history[h])
+	}
+	e.pos = len(e.buf)
+}
+
+// render returns what's needed to draw the prompt & line over the current
+// terminal line. Lines too long for the width scroll to keep the cursor
+// on screen.
+func (e *Editor) render(prompt string, width int) string {
+	start, text := 0, e.buf
+	if avail := width - utf8.RuneCountInString(prompt) - 1; width > 0 && avail > 0 && len(text) > avail {
+		if e.pos > avail {
+			start = e.pos - avail
+		}
+		end := start + avail
+		if end > len(text) {
+			end = len(text)
+		}
+		text = text[start:end]
+	}
+
+	out := fmt.Sprintf("\r%v%v\x1b[K\r", prompt, string(text))
+	if col := utf8.RuneCountInString(prompt) + e.pos - start; col > 0 {
+		out += fmt.Sprintf("\x1b[%vC", col)
+	}
+	return out
+}
+
+// ReadLine shows the prompt & reads a line from the terminal. The
+// terminal is only in raw mode while the line is being read.
+func (e *Editor) ReadLine(in, out *os.File, prompt string) (string, error) {
+	restore, err := term.MakeRaw(in)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	e.reset()
+	buf := make([]byte, 256)
+	for {
+		width, _, err := term.Size(out)
+		if err != nil {
+			width = 0
+		}
+
+		for len(e.pending) > 0 {
+			k := e.pending[0]
+			e.pending = e.pending[1:]
+
+			line, done, err := e.HandleKey(k)
+			if !done {
+				continue
+			}
+
+			end := "\r\n"
+			if err == ErrInterrupted {
+				end = "^C\r\n"
+			}
+			io.WriteString(out, e.render(prompt, width)+end)
+			return line, err
+		}
+
+		if _, err := io.WriteString(out, e.render(prompt, width)); err != nil {
+			return "", err
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		e.pending = term.ParseKeys(buf[:n])
+	}
+}
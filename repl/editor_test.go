@@ -0,0 +1,94 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+func TestRepl_Editor_HandleKey(t *testing.T) {
+	tests := []struct {
+		history []string
+		input   string
+		expect  string
+		err     error
+	}{
+		{nil, "A 1, B 0\r", "A 1, B 0", nil},
+		{nil, "A 1 B 0\x1b[D\x1b[D\x1b[D\x1b[D,\r", "A 1, B 0", nil},
+		{nil, "A 1, B 0\x7f\x7f\r", "A 1, B", nil},
+		{nil, "xA 1\x01\x1b[3~\x05, B 2\r", "A 1, B 2", nil},
+		{nil, "junk A 1\x1b[D\x1b[D\x1b[D\x15\r", "A 1", nil},
+		{[]string{"one", "two"}, "\x1b[A\r", "two", nil},
+		{[]string{"one", "two"}, "\x1b[A\x1b[A\x1b[A!\r", "one!", nil},
+		{[]string{"one", "two"}, "new\x1b[A\x1b[B\r", "new", nil},
+		{nil, "abc\x03", "", ErrInterrupted},
+		{nil, "\x04", "", io.EOF},
+		{nil, "ab\x1b[D\x04\r", "a", nil},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			e := NewEditor()
+			for _, h := range tt.history {
+				e.AddHistory(h)
+			}
+			e.reset()
+
+			for _, k := range term.ParseKeys([]byte(tt.input)) {
+				line, done, err := e.HandleKey(k)
+				if !done {
+					continue
+				}
+				if err != tt.err {
+					t.Fatalf("wrong error, expected %v got %v", tt.err, err)
+				}
+				if line != tt.expect {
+					t.Errorf("wrong line, expected '%v' got '%v'", tt.expect, line)
+				}
+				return
+			}
+			t.Errorf("line was never finished")
+		})
+	}
+}
+
+func TestRepl_Editor_History(t *testing.T) {
+	e := NewEditor()
+	for _, l := range []string{"a", "", "b", "b", "a"} {
+		e.AddHistory(l)
+	}
+
+	expect := []string{"a", "b", "a"}
+	if fmt.Sprint(e.history) != fmt.Sprint(expect) {
+		t.Errorf("wrong history, expected %v got %v", expect, e.history)
+	}
+}
+
+func TestRepl_Editor_Render(t *testing.T) {
+	tests := []struct {
+		line   string
+		pos    int
+		width  int
+		expect string
+	}{
+		{"", 0, 80, "\r> \x1b[K\r\x1b[2C"},
+		{"A 1, B 0", 8, 80, "\r> A 1, B 0\x1b[K\r\x1b[10C"},
+		{"A 1, B 0", 3, 80, "\r> A 1, B 0\x1b[K\r\x1b[5C"},
+		{"A 1, B 0", 8, 7, "\r>  B 0\x1b[K\r\x1b[6C"},
+		{"A 1, B 0", 0, 7, "\r> A 1,\x1b[K\r\x1b[2C"},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			e := NewEditor()
+			e.buf, e.pos = []rune(tt.line), tt.pos
+			if got := e.render("> ", tt.width); got != tt.expect {
+				t.Errorf("wrong render, expected %q got %q", tt.expect, got)
+			}
+		})
+	}
+}
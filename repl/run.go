@@ -0,0 +1,46 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/seanhagen/jane-coding-challenge/term"
+)
+
+// Run reads lines from in and runs them in the session until the input
+// ends or the session is quit. When in is a terminal the lines can be
+// edited, and earlier lines are available with the up arrow; otherwise
+// lines are read as-is with no prompt, so commands can be piped in.
+func Run(in, out *os.File, s *Session) error {
+	if !term.IsTerminal(in) {
+		sc := bufio.NewScanner(in)
+		for sc.Scan() {
+			if !s.Exec(sc.Text()) {
+				return nil
+			}
+		}
+		return sc.Err()
+	}
+
+	fmt.Fprintln(out, "Enter match lines or commands, :help for help")
+	ed := NewEditor()
+	for {
+		line, err := ed.ReadLine(in, out, fmt.Sprintf("matchday %v> ", s.Day()))
+		switch err {
+		case nil:
+		case ErrInterrupted:
+			continue
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+
+		ed.AddHistory(line)
+		if !s.Exec(line) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,486 @@
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/input"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
+)
+
+// CommandPrefix marks a line as a command instead of a match line
+const CommandPrefix = ":"
+
+// Session is a ranking that's built up a line at a time. Every entry
+// that's been added is kept, so that entries can be undone & the whole
+// session saved as match data.
+type Session struct {
+	out io.Writer
+
+	// newRanking creates an empty ranking, with any aliases or name
+	// normalization already set up
+	newRanking func() (*games.Ranking, error)
+
+	ranking *games.Ranking
+	entries []entry
+
+	loc  *locale.Locale
+	opts output.TerminalOptions
+}
+
+// entry is an entry that's been added to the session, along with the
+// match it added so it can be found again by :edit & :delete
+type entry struct {
+	input.Entry
+
+	// day is zero for entries that don't add a match, such as
+	// deductions or voided results
+	day          int
+	team1, team2 string
+}
+
+// NewSession creates a session that writes to out. The locale & options
+// are used for the tables shown by the :table & :day commands.
+func NewSession(out io.Writer, newRanking func() (*games.Ranking, error), loc *locale.Locale, opts output.TerminalOptions) (*Session, error) {
+	r, err := newRanking()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{out: out, newRanking: newRanking, ranking: r, loc: loc, opts: opts}, nil
+}
+
+// Add adds an entry to the ranking. If the entry can't be added the
+// ranking is rebuilt from the earlier entries, so nothing from a bad
+// entry is left behind.
+func (s *Session) Add(e input.Entry) error {
+	added, err := addTo(s.ranking, e)
+	if err != nil {
+		if rerr := s.rebuild(); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+	s.entries = append(s.entries, added)
+	return nil
+}
+
+// addTo adds the entry to the ranking, noting which match it added
+func addTo(r *games.Ranking, e input.Entry) (entry, error) {
+	before := matchCount(r.Model())
+	if err := e.AddTo(r); err != nil {
+		return entry{}, err
+	}
+
+	added := entry{Entry: e}
+	if m := r.Model(); matchCount(m) > before {
+		d := m.Days[len(m.Days)-1]
+		mt := d.Matches[len(d.Matches)-1]
+		added.day, added.team1, added.team2 = d.Number, mt.Team1, mt.Team2
+	}
+	return added, nil
+}
+
+// matchCount returns how many matches are in the season
+func matchCount(m games.Season) int {
+	n := 0
+	for _, d := range m.Days {
+		n += len(d.Matches)
+	}
+	return n
+}
+
+// rebuild creates a new ranking from the session's entries
+func (s *Session) rebuild() error {
+	r, err := s.newRanking()
+	if err != nil {
+		return err
+	}
+
+	entries := []entry{}
+	for _, e := range s.entries {
+		added, err := addTo(r, e.Entry)
+		if err != nil {
+			return fmt.Errorf("unable to rebuild the ranking, %v: %w", e.Location, err)
+		}
+		entries = append(entries, added)
+	}
+	s.ranking, s.entries = r, entries
+	return nil
+}
+
+// find returns the index of the entry that added the match between
+// the two teams on the given day, or -1 if there isn't one. The latest
+// entry is used, since an earlier one may have been removed since.
+func (s *Session) find(day int, team1, team2 string) int {
+	n1, _ := s.ranking.TeamName(team1)
+	n2, _ := s.ranking.TeamName(team2)
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+		if e.day == day && ((e.team1 == n1 && e.team2 == n2) || (e.team1 == n2 && e.team2 == n1)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Day returns the number of the current match day
+func (s *Session) Day() int {
+	m := s.ranking.Model()
+	if l := len(m.Days); l > 0 {
+		return m.Days[l-1].Number
+	}
+	return games.StartMatchDay
+}
+
+// Exec runs a line typed into the session, either a match line or a
+// command. It returns false when the session should end.
+func (s *Session) Exec(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return true
+	}
+
+	if !strings.HasPrefix(line, CommandPrefix) {
+		e := input.Entry{Location: fmt.Sprintf("entry %v", len(s.entries)+1), Line: line}
+		seen := len(s.ranking.Warnings())
+		if err := s.Add(e); err != nil {
+			s.error(err)
+			return true
+		}
+		if !strings.HasPrefix(line, games.DirectivePrefix) {
+			fmt.Fprintf(s.out, "added to %v\n", strings.ToLower(s.loc.Message(locale.MsgMatchday, s.Day())))
+		}
+		for _, w := range s.ranking.Warnings()[seen:] {
+			fmt.Fprintf(s.out, "warning: %v\n", w)
+		}
+		return true
+	}
+
+	name, arg := line[len(CommandPrefix):], ""
+	if i := strings.IndexAny(name, " \t"); i >= 0 {
+		name, arg = name[:i], strings.TrimSpace(name[i:])
+	}
+
+	for _, c := range commands {
+		if c.name != name && !(c.short != "" && c.short == name) {
+			continue
+		}
+		if c.run == nil {
+			return false
+		}
+		if err := c.run(s, arg); err != nil {
+			s.error(err)
+		}
+		return true
+	}
+
+	s.error(fmt.Errorf("unknown command '%v', try :help", line))
+	return true
+}
+
+// error shows an error, with a hint for the errors that come from
+// badly formatted input
+func (s *Session) error(err error) {
+	fmt.Fprintf(s.out, "error: %v\n", err)
+
+	var ple *games.ParseLineError
+	var pte *games.ParseTeamError
+	var de *games.DirectiveError
+	switch {
+	case errors.As(err, &ple), errors.As(err, &pte):
+		fmt.Fprintf(s.out, "  match lines look like: Team A 1, Team B 2\n")
+	case errors.As(err, &de):
+		fmt.Fprintf(s.out, "  directives look like: !deduct \"Team A\" 3 \"reason\"\n")
+	}
+}
+
+// command is something that can be run from the session
+type command struct {
+	name  string
+	short string
+	args  string
+	help  string
+
+	// run is nil for the command that ends the session
+	run func(s *Session, arg string) error
+}
+
+// commands are all the commands the session understands
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "table", short: "t", help: "show the current league table", run: (*Session).table},
+		{name: "day", short: "d", args: "[n]", help: "show the results & table for a match day, the current day by default", run: (*Session).day},
+		{name: "team", args: "<name>", help: "show every match a team has played", run: (*Session).team},
+		{name: "undo", short: "u", help: "remove the last match or directive", run: (*Session).undo},
+		{name: "edit", short: "e", args: "<day> <match>", help: "correct the score of a match, like :edit 2 Team A 1, Team B 2", run: (*Session).edit},
+		{name: "delete", args: "<day> <teams>", help: "remove a match, like :delete 2 Team A, Team B", run: (*Session).delete},
+		{name: "save", args: "<file>", help: "save everything entered so far as match data", run: (*Session).save},
+		{name: "help", short: "h", help: "show this help", run: (*Session).help},
+		{name: "quit", short: "q", help: "end the session"},
+	}
+}
+
+// table shows the standings for the current match day
+func (s *Session) table(arg string) error {
+	m := s.ranking.Model()
+	l := len(m.Days)
+	if l == 0 || len(m.Days[l-1].Standings) == 0 {
+		return fmt.Errorf("no matches yet")
+	}
+
+	prev := []games.Standing{}
+	if l > 1 {
+		prev = m.Days[l-2].Standings
+	}
+	return output.WriteStandings(s.out, s.loc, m.Days[l-1].Standings, prev, s.opts)
+}
+
+// day shows the results & standings for a match day
+func (s *Session) day(arg string) error {
+	m := s.ranking.Model()
+	if len(m.Days) == 0 {
+		return fmt.Errorf("no matches yet")
+	}
+
+	i := len(m.Days) - 1
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("expected a match day number, got '%v'", arg)
+		}
+		if i = n - games.StartMatchDay; i < 0 || i >= len(m.Days) {
+			return fmt.Errorf("no match day %v, there are %v so far", n, len(m.Days))
+		}
+	}
+
+	d := m.Days[i]
+	fmt.Fprintf(s.out, "%v\n", s.loc.Message(locale.MsgMatchday, d.Number))
+	for _, mt := range d.Matches {
+		l := fmt.Sprintf("  %v %v - %v %v", mt.Team1, mt.Score1, mt.Score2, mt.Team2)
+		switch {
+		case mt.Status == "postponed":
+			l = fmt.Sprintf("  %v v %v", mt.Team1, mt.Team2)
+		case mt.Forfeit != "":
+			l = fmt.Sprintf("%v, %v forfeited", l, mt.Forfeit)
+		}
+		if mt.Status != "played" {
+			l = fmt.Sprintf("%v (%v)", l, mt.Status)
+		}
+		fmt.Fprintln(s.out, l)
+	}
+	fmt.Fprintln(s.out)
+
+	prev := []games.Standing{}
+	if i > 0 {
+		prev = m.Days[i-1].Standings
+	}
+	return output.WriteStandings(s.out, s.loc, d.Standings, prev, s.opts)
+}
+
+// team shows every match the named team has played
+func (s *Session) team(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("expected a team name, like :team Aptos FC")
+	}
+
+	m := s.ranking.Model()
+	name := ""
+	for _, t := range m.Teams {
+		if strings.EqualFold(t, arg) {
+			name = t
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("no team named '%v'", arg)
+	}
+
+	fmt.Fprintf(s.out, "%v\n", name)
+	for _, tm := range m.TeamMatches(name) {
+		score := fmt.Sprintf("%v-%v %v", tm.GoalsFor, tm.GoalsAgainst, tm.Result)
+		if tm.Status != "played" {
+			score = tm.Status
+		}
+		fmt.Fprintf(s.out, "  %v  v %v  %v  %v %v, %v\n", s.loc.Message(locale.MsgMatchday, tm.Day), tm.Opponent, score, tm.Points, s.loc.PluralMessage(locale.MsgPoints, tm.Points), ordinal(tm.Position))
+	}
+	return nil
+}
+
+// undo removes the last entry. Matches are removed from the ranking
+// directly, anything else means rebuilding the ranking without it.
+func (s *Session) undo(arg string) error {
+	l := len(s.entries)
+	if l == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+
+	last := s.entries[l-1]
+	if last.day > 0 {
+		if err := s.ranking.RemoveMatch(last.day, last.team1, last.team2); err != nil {
+			return err
+		}
+		s.entries = s.entries[:l-1]
+	} else {
+		s.entries = s.entries[:l-1]
+		if err := s.rebuild(); err != nil {
+			s.entries = append(s.entries, last)
+			return err
+		}
+	}
+
+	fmt.Fprintf(s.out, "removed: %v\n", last.text())
+	return nil
+}
+
+// edit corrects the score of a match on an earlier day
+func (s *Session) edit(arg string) error {
+	day, rest, err := splitDay(arg)
+	if err != nil || rest == "" {
+		return fmt.Errorf("expected a match day and the corrected match, like :edit 2 Team A 1, Team B 2")
+	}
+
+	mr, err := games.ParseMatchRecord(rest)
+	if err != nil {
+		return err
+	}
+	if err = s.ranking.AmendMatch(day, mr); err != nil {
+		return err
+	}
+
+	// the entry is replaced so that saving or rebuilding the session
+	// uses the corrected score
+	if i := s.find(day, mr.Team1, mr.Team2); i >= 0 {
+		s.entries[i].Line, s.entries[i].Record = rest, nil
+	}
+	fmt.Fprintf(s.out, "updated %v\n", strings.ToLower(s.loc.Message(locale.MsgMatchday, day)))
+	return nil
+}
+
+// delete removes a match from an earlier day
+func (s *Session) delete(arg string) error {
+	day, rest, err := splitDay(arg)
+	if err != nil || rest == "" {
+		return fmt.Errorf("expected a match day and two teams, like :delete 2 Team A, Team B")
+	}
+
+	t1, t2, err := games.ParseFixture(rest)
+	if err != nil {
+		return err
+	}
+
+	i := s.find(day, t1, t2)
+	if err = s.ranking.RemoveMatch(day, t1, t2); err != nil {
+		return err
+	}
+
+	what := rest
+	if i >= 0 {
+		what = s.entries[i].text()
+	}
+	if i >= 0 && i == len(s.entries)-1 {
+		s.entries = s.entries[:i]
+	} else {
+		// the removal is recorded after the entries instead of dropping
+		// the match's entry, so that rebuilding or saving the session
+		// plays the entries onto the same days they were added to
+		n1, _ := s.ranking.TeamName(t1)
+		n2, _ := s.ranking.TeamName(t2)
+		e := input.Entry{Location: fmt.Sprintf("entry %v", len(s.entries)+1), Line: games.RemoveDirective(day, n1, n2)}
+		s.entries = append(s.entries, entry{Entry: e})
+	}
+	fmt.Fprintf(s.out, "removed from %v: %v\n", strings.ToLower(s.loc.Message(locale.MsgMatchday, day)), what)
+	return nil
+}
+
+// splitDay splits a match day number off the start of a command's argument
+func splitDay(arg string) (int, string, error) {
+	fields := strings.SplitN(arg, " ", 2)
+	day, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, "", err
+	}
+	if len(fields) == 1 {
+		return day, "", nil
+	}
+	return day, strings.TrimSpace(fields[1]), nil
+}
+
+// save writes the entries to a file as match lines
+func (s *Session) save(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("expected a file name, like :save matchday.txt")
+	}
+
+	f, err := os.Create(arg)
+	if err != nil {
+		return fmt.Errorf("unable to create file: %w", err)
+	}
+	if err = s.WriteEntries(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("unable to save file: %w", err)
+	}
+
+	fmt.Fprintf(s.out, "saved %v entries to %v\n", len(s.entries), arg)
+	return nil
+}
+
+// WriteEntries writes every entry as a line of text match data
+func (s *Session) WriteEntries(w io.Writer) error {
+	for _, e := range s.entries {
+		if _, err := fmt.Fprintln(w, e.text()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text returns the entry as a line of text match data
+func (e entry) text() string {
+	if e.Record != nil {
+		return e.Record.String()
+	}
+	return e.Line
+}
+
+// help lists the commands
+func (s *Session) help(arg string) error {
+	fmt.Fprintln(s.out, "Type a match line to add it, like: Team A 1, Team B 2")
+	fmt.Fprintln(s.out, "Directives like !deduct and !forfeit work the same as in match data files.")
+	fmt.Fprintln(s.out, "Commands:")
+	for _, c := range commands {
+		use := CommandPrefix + c.name
+		if c.args != "" {
+			use = fmt.Sprintf("%v %v", use, c.args)
+		}
+		if c.short != "" {
+			use = fmt.Sprintf("%v, %v%v", use, CommandPrefix, c.short)
+		}
+		fmt.Fprintf(s.out, "  %-24v %v\n", use, c.help)
+	}
+	return nil
+}
+
+// ordinal returns the number with an English ordinal suffix, like "1st"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%v%v", n, suffix)
+}
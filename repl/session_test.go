@@ -0,0 +1,280 @@
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+	"github.com/seanhagen/jane-coding-challenge/input"
+	"github.com/seanhagen/jane-coding-challenge/locale"
+	"github.com/seanhagen/jane-coding-challenge/output"
+)
+
+// newTestSession creates a session that writes to the buffer
+func newTestSession(t *testing.T, out *bytes.Buffer) *Session {
+	en, err := locale.Get("en")
+	if err != nil {
+		t.Fatalf("unable to get locale: %v", err)
+	}
+
+	newRanking := func() (*games.Ranking, error) { return games.NewRanking(), nil }
+	s, err := NewSession(out, newRanking, en, output.TerminalOptions{})
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	return s
+}
+
+func TestRepl_Session_Exec(t *testing.T) {
+	tests := []struct {
+		lines  []string
+		expect string
+	}{
+		{[]string{"A 1, B 0", "C 0, D 0", ":table"}, `added to matchday 1
+added to matchday 1
+Pos    Team  Pts
+  1    A       3
+  2    C       1
+  3    D       1
+  4    B       0
+`},
+		{[]string{"A 1, B 0", "A x, C 1", "A 1 C 1", ":day"}, `added to matchday 1
+error: unable to parse 'x' for score: strconv.Atoi: parsing "x": invalid syntax
+  match lines look like: Team A 1, Team B 2
+error: wrong number of parts in match string 'A 1 C 1'
+  match lines look like: Team A 1, Team B 2
+Matchday 1
+  A 1 - 0 B
+
+Pos    Team  Pts
+  1    A       3
+  2    B       0
+`},
+		{[]string{"A 1, B 0", "A 2, B 2", ":undo", ":day 2", ":undo", ":undo"}, `added to matchday 1
+added to matchday 2
+removed: A 2, B 2
+error: no match day 2, there are 1 so far
+removed: A 1, B 0
+error: nothing to undo
+`},
+		{[]string{"Aptos 1, Capitola 0", "Aptos 0, Capitola 0", ":team APTOS", ":team"}, `added to matchday 1
+added to matchday 2
+Aptos
+  Matchday 1  v Capitola  1-0 W  3 pts, 1st
+  Matchday 2  v Capitola  0-0 D  4 pts, 1st
+error: expected a team name, like :team Aptos FC
+`},
+		{[]string{"!deduct A", "A 1, B 1", "!deduct A 1", ":t"}, `error: unable to apply directive '!deduct A': expected a team, points, and an optional reason
+  directives look like: !deduct "Team A" 3 "reason"
+added to matchday 1
+Pos    Team  Pts  Note
+  1    B       1
+  2    A       0  -1 pt
+`},
+		{[]string{"Aptos FC 1, Capitola 0", "Aptos FX 1, Davenport 0"}, `added to matchday 1
+added to matchday 1
+warning: new team 'Aptos FX' is similar to existing team 'Aptos FC', did you mean 'Aptos FC'?
+`},
+		{[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0", ":edit 1 B 2, A 0", ":delete 2 A, C", ":table", ":edit 2 A 1, C 1", ":delete x"}, `added to matchday 1
+added to matchday 1
+added to matchday 2
+updated matchday 1
+removed from matchday 2: A 2, C 0
+Pos    Team  Pts
+  1    B       3
+  2    C       1
+  3    D       1
+  4    A       0
+error: no recorded match between 'A' and 'C' on day 2
+error: expected a match day and two teams, like :delete 2 Team A, Team B
+`},
+		{[]string{":nope", "   ", ":t"}, `error: unknown command ':nope', try :help
+error: no matches yet
+`},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			s := newTestSession(t, out)
+			for _, l := range tt.lines {
+				if !s.Exec(l) {
+					t.Fatalf("session ended on line '%v'", l)
+				}
+			}
+			if out.String() != tt.expect {
+				t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(tt.expect, out.String()))
+			}
+		})
+	}
+}
+
+func TestRepl_Session_Quit(t *testing.T) {
+	for i, x := range []string{":quit", ":q"} {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			s := newTestSession(t, &bytes.Buffer{})
+			if s.Exec(tt) {
+				t.Errorf("expected '%v' to end the session", tt)
+			}
+		})
+	}
+}
+
+func TestRepl_Session_Save(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := newTestSession(t, out)
+
+	one := 1
+	lines := []string{"A 1, B 0", "bad line", `!forfeit "C" "D"`}
+	for _, l := range lines {
+		s.Exec(l)
+	}
+	if err := s.Add(inputRecord(games.MatchRecord{Team1: "E|F", Score1: &one, Team2: "G", Status: "P"})); err != nil {
+		t.Fatalf("unable to add record: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "saved.txt")
+	s.Exec(":save " + path)
+	if !strings.HasSuffix(out.String(), fmt.Sprintf("saved 3 entries to %v\n", path)) {
+		t.Errorf("expected saved message, got:\n%v", out.String())
+	}
+
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read saved file: %v", err)
+	}
+	expect := `A 1, B 0
+!forfeit "C" "D"
+"E|F" | 1 | G |  | P
+`
+	if string(got) != expect {
+		t.Errorf("wrong saved file\ndiff:\n%v", diff.LineDiff(expect, string(got)))
+	}
+
+	// the saved file should build the same ranking
+	r := games.NewRanking()
+	for _, l := range strings.Split(strings.TrimSpace(string(got)), "\n") {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add saved line '%v': %v", l, err)
+		}
+	}
+	if r.Results() != s.ranking.Results() {
+		t.Errorf("saved file gives different results\ndiff:\n%v", diff.LineDiff(s.ranking.Results(), r.Results()))
+	}
+}
+
+// inputRecord wraps a match record in an input entry
+func inputRecord(mr games.MatchRecord) input.Entry {
+	return input.Entry{Location: "record", Record: &mr}
+}
+
+func TestRepl_Session_EditSave(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := newTestSession(t, out)
+	for _, l := range []string{"A 1, B 0", `!forfeit "C" "D"`, "A 2, C 0", "B 1, D 1", ":edit 1 D 1, C 1", ":delete 2 B, D", "B 0, D 0"} {
+		s.Exec(l)
+	}
+
+	got := &bytes.Buffer{}
+	if err := s.WriteEntries(got); err != nil {
+		t.Fatalf("unable to write entries: %v", err)
+	}
+	expect := `A 1, B 0
+D 1, C 1
+A 2, C 0
+B 0, D 0
+`
+	if got.String() != expect {
+		t.Errorf("wrong entries\ndiff:\n%v", diff.LineDiff(expect, got.String()))
+	}
+
+	// the entries should build the same ranking as the edited one
+	r := games.NewRanking()
+	for _, l := range strings.Split(strings.TrimSpace(got.String()), "\n") {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add entry '%v': %v", l, err)
+		}
+	}
+	if r.Results() != s.ranking.Results() {
+		t.Errorf("saved entries don't match the session\ndiff:\n%v", diff.LineDiff(s.ranking.Results(), r.Results()))
+	}
+}
+
+// TestRepl_Session_DeleteEarlierDay checks the session can still be rebuilt
+// & saved after a match on an earlier day is deleted
+func TestRepl_Session_DeleteEarlierDay(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := newTestSession(t, out)
+	for _, l := range []string{"A 1, B 0", "C 1, D 1", "A 2, C 0", "B 1, D 1", ":delete 1 A, B", "oops", `!deduct "C" 1`, ":undo", ":table"} {
+		s.Exec(l)
+	}
+
+	expect := `added to matchday 1
+added to matchday 1
+added to matchday 2
+added to matchday 2
+removed from matchday 1: A 1, B 0
+error: wrong number of parts in match string 'oops'
+  match lines look like: Team A 1, Team B 2
+removed: !deduct "C" 1
+Pos      Team  Pts
+  1      A       3
+  2  =   D       2
+  3      B       1
+  4  ▼3  C       1
+`
+	if out.String() != expect {
+		t.Errorf("wrong output\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+
+	got := &bytes.Buffer{}
+	if err := s.WriteEntries(got); err != nil {
+		t.Fatalf("unable to write entries: %v", err)
+	}
+	entries := `A 1, B 0
+C 1, D 1
+A 2, C 0
+B 1, D 1
+!remove "A" "B" from 1
+`
+	if got.String() != entries {
+		t.Errorf("wrong entries\ndiff:\n%v", diff.LineDiff(entries, got.String()))
+	}
+
+	// the saved entries should build the same ranking as the session
+	r := games.NewRanking()
+	for _, l := range strings.Split(strings.TrimSpace(got.String()), "\n") {
+		if err := r.AddMatch(l); err != nil {
+			t.Fatalf("unable to add entry '%v': %v", l, err)
+		}
+	}
+	if r.Results() != s.ranking.Results() {
+		t.Errorf("saved entries don't match the session\ndiff:\n%v", diff.LineDiff(s.ranking.Results(), r.Results()))
+	}
+
+	// undoing the delete puts the match back
+	out.Reset()
+	s.Exec(":undo")
+	s.Exec(":day 1")
+	expect = `removed: !remove "A" "B" from 1
+Matchday 1
+  A 1 - 0 B
+  C 1 - 1 D
+
+Pos    Team  Pts
+  1    A       3
+  2    C       1
+  3    D       1
+  4    B       0
+`
+	if out.String() != expect {
+		t.Errorf("wrong output after undo\ndiff:\n%v", diff.LineDiff(expect, out.String()))
+	}
+}
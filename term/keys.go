@@ -0,0 +1,123 @@
+package term
+
+import (
+	"unicode/utf8"
+)
+
+// KeyCode identifies a key that isn't a printable character
+type KeyCode int
+
+const (
+	// KeyRune is a printable character, see Key.Rune
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeyDelete
+	KeyCtrlA
+	KeyCtrlC
+	KeyCtrlD
+	KeyCtrlE
+	KeyCtrlU
+)
+
+// Key is a single key press
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// escapes are the sequences terminals send for the special keys
+var escapes = map[string]KeyCode{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1b[C":  KeyRight,
+	"\x1b[D":  KeyLeft,
+	"\x1bOA":  KeyUp,
+	"\x1bOB":  KeyDown,
+	"\x1bOC":  KeyRight,
+	"\x1bOD":  KeyLeft,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1bOH":  KeyHome,
+	"\x1bOF":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+	"\x1b[7~": KeyHome,
+	"\x1b[8~": KeyEnd,
+	"\x1b[3~": KeyDelete,
+}
+
+// controls are the control characters that are turned into keys
+var controls = map[byte]KeyCode{
+	'\r': KeyEnter,
+	'\n': KeyEnter,
+	0x7f: KeyBackspace,
+	0x08: KeyBackspace,
+	'\t': KeyTab,
+	0x01: KeyCtrlA,
+	0x03: KeyCtrlC,
+	0x04: KeyCtrlD,
+	0x05: KeyCtrlE,
+	0x15: KeyCtrlU,
+}
+
+// ParseKeys turns the bytes read from the terminal into key presses.
+// Escape sequences are expected to arrive in a single read, so an
+// escape at the end of the input is the escape key itself.
+func ParseKeys(b []byte) []Key {
+	keys := []Key{}
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			n, code, ok := matchEscape(b)
+			if ok {
+				keys = append(keys, Key{Code: code})
+			}
+			b = b[n:]
+			continue
+		}
+
+		if code, ok := controls[b[0]]; ok {
+			keys = append(keys, Key{Code: code})
+			b = b[1:]
+			continue
+		}
+
+		r, n := utf8.DecodeRune(b)
+		if r >= ' ' {
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+		}
+		b = b[n:]
+	}
+	return keys
+}
+
+// matchEscape returns the length & key of the escape sequence at the
+// start of b. Unknown sequences are skipped up to their final byte and
+// ok is false.
+func matchEscape(b []byte) (n int, code KeyCode, ok bool) {
+	for seq, c := range escapes {
+		if len(b) >= len(seq) && string(b[:len(seq)]) == seq {
+			return len(seq), c, true
+		}
+	}
+
+	if len(b) < 2 || (b[1] != '[' && b[1] != 'O') {
+		return 1, KeyEscape, true
+	}
+
+	// an unknown CSI sequence ends in a byte from '@' to '~'
+	for i := 2; i < len(b); i++ {
+		if b[i] >= '@' && b[i] <= '~' {
+			return i + 1, KeyEscape, false
+		}
+	}
+	return len(b), KeyEscape, false
+}
@@ -0,0 +1,34 @@
+package term
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTerm_ParseKeys(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect []Key
+	}{
+		{"q", []Key{{Code: KeyRune, Rune: 'q'}}},
+		{"\x1b[A\x1b[B", []Key{{Code: KeyUp}, {Code: KeyDown}}},
+		{"\x1bOC", []Key{{Code: KeyRight}}},
+		{"\x1b[1~\x1b[F", []Key{{Code: KeyHome}, {Code: KeyEnd}}},
+		{"\x1b", []Key{{Code: KeyEscape}}},
+		{"\r\x7f\t\x03", []Key{{Code: KeyEnter}, {Code: KeyBackspace}, {Code: KeyTab}, {Code: KeyCtrlC}}},
+		{"é/", []Key{{Code: KeyRune, Rune: 'é'}, {Code: KeyRune, Rune: '/'}}},
+		{"\x1b[15~x", []Key{{Code: KeyRune, Rune: 'x'}}},
+		{"\x01\x04\x05\x15\x1b[3~", []Key{{Code: KeyCtrlA}, {Code: KeyCtrlD}, {Code: KeyCtrlE}, {Code: KeyCtrlU}, {Code: KeyDelete}}},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			got := ParseKeys([]byte(tt.in))
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("wrong keys, expected %+v got %+v", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package term
+
+import "os"
+
+// IsTerminal returns true if the file is a terminal
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,24 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package term
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// MakeRaw isn't supported on this platform
+func MakeRaw(f *os.File) (func() error, error) {
+	return nil, fmt.Errorf("raw terminal mode isn't supported on %v", runtime.GOOS)
+}
+
+// Size isn't supported on this platform
+func Size(f *os.File) (int, int, error) {
+	return 0, 0, fmt.Errorf("raw terminal mode isn't supported on %v", runtime.GOOS)
+}
+
+// NotifyResize isn't supported on this platform
+func NotifyResize(c chan os.Signal) func() {
+	return func() {}
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// The event names used in payloads & the event header
+const (
+	EventDayClosed     = "day_closed"
+	EventLeaderChanged = "leader_changed"
+)
+
+// Payload is the JSON body POSTed for an event. Standings is only set for
+// day_closed, the leader fields only for leader_changed.
+type Payload struct {
+	Event string `json:"event"`
+	Day   int    `json:"day"`
+
+	Standings []Standing `json:"standings,omitempty"`
+
+	Leader   string `json:"leader,omitempty"`
+	Previous string `json:"previous,omitempty"`
+	Points   int    `json:"points,omitempty"`
+}
+
+// Standing is a team's place in the table in a day_closed payload
+type Standing struct {
+	Position int    `json:"position"`
+	Team     string `json:"team"`
+	Points   int    `json:"points"`
+	Note     string `json:"note,omitempty"`
+}
+
+// newPayload returns the payload for the event, false if the event isn't
+// one that's sent to webhooks
+func newPayload(e games.Event) (Payload, bool) {
+	switch ev := e.(type) {
+	case games.DayClosedEvent:
+		p := Payload{Event: EventDayClosed, Day: ev.Day, Standings: []Standing{}}
+		for _, st := range ev.Standings {
+			p.Standings = append(p.Standings, Standing{Position: st.Position, Team: st.Team, Points: st.Points, Note: st.Note})
+		}
+		return p, true
+
+	case games.LeaderChangedEvent:
+		return Payload{Event: EventLeaderChanged, Day: ev.Day, Leader: ev.Leader, Previous: ev.Previous, Points: ev.Points}, true
+	}
+	return Payload{}, false
+}
+
+// encode returns the JSON body for the payload
+func (p Payload) encode() ([]byte, error) {
+	return json.Marshal(p)
+}
@@ -0,0 +1,324 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// The headers sent with every delivery. The signature is the hex encoded
+// HMAC-SHA256 of the body, prefixed with "sha256=", and is only sent when
+// there's a secret.
+const (
+	EventHeader     = "X-Rankings-Event"
+	SignatureHeader = "X-Rankings-Signature"
+)
+
+// Defaults used for any Config fields left as zero
+const (
+	DefaultAttempts = 5
+	DefaultBackoff  = time.Second
+	DefaultTimeout  = 10 * time.Second
+)
+
+// ErrStopped is the error in the dead letter for a delivery that hadn't
+// been made when Stop gave up waiting for it
+var ErrStopped = errors.New("stopped before the delivery could be made")
+
+// Config sets up a Dispatcher
+type Config struct {
+	// URLs are where every payload is POSTed
+	URLs []string
+
+	// Secret is the key used to sign payloads, they aren't signed
+	// if it's empty
+	Secret string
+
+	// Attempts is how many times a delivery is tried before giving up,
+	// Backoff is how long to wait after the first failed try. The wait
+	// doubles after each failure.
+	Attempts int
+	Backoff  time.Duration
+
+	// DeadLetter is a file that deliveries are appended to, one JSON
+	// object per line, when they can't be made. If it's empty failures
+	// are only reported to Errors.
+	DeadLetter string
+
+	// Errors is where failed deliveries are reported, nothing is
+	// reported if it's nil
+	Errors io.Writer
+
+	// Client is used to make the requests, a client with a
+	// DefaultTimeout is used if it's nil
+	Client *http.Client
+}
+
+// Dispatcher POSTs a JSON payload to each URL when a match day closes or
+// the leader changes. Use Subscribe to send a ranking's events to it.
+//
+// Deliveries are made in the background, in the order the events happen.
+// Failed deliveries are tried again if the request couldn't be made, or
+// the response was a 5xx or 429; any other response isn't tried again.
+type Dispatcher struct {
+	cfg   Config
+	queue chan delivery
+	done  chan struct{}
+
+	// ctx is cancelled by Stop to give up on the deliveries left
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// sent is a hash of each payload from the ranking being built, and
+	// previous the same for the one before it, so a ranking that's built
+	// again from the start only sends the payloads that have changed
+	sent, previous map[sentKey][sha256.Size]byte
+}
+
+// sentKey identifies a payload from a ranking, n counts the payloads
+// before it with the same event & day
+type sentKey struct {
+	event string
+	day   int
+	n     int
+}
+
+// delivery is a payload waiting to be sent
+type delivery struct {
+	event string
+	body  []byte
+}
+
+// DeadLetter is a delivery that couldn't be made, as written to the
+// dead letter file
+type DeadLetter struct {
+	Time     time.Time       `json:"time"`
+	URL      string          `json:"url"`
+	Event    string          `json:"event"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// New creates a dispatcher & starts sending deliveries, Close or Stop has
+// to be called to wait for them to finish
+func New(cfg Config) (*Dispatcher, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, fmt.Errorf("no webhook URLs given")
+	}
+	for _, u := range cfg.URLs {
+		req, err := http.NewRequest(http.MethodPost, u, nil)
+		if err != nil || (req.URL.Scheme != "http" && req.URL.Scheme != "https") {
+			return nil, fmt.Errorf("invalid webhook URL '%v', expected an http or https URL", u)
+		}
+	}
+
+	if cfg.Attempts <= 0 {
+		cfg.Attempts = DefaultAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = DefaultBackoff
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if cfg.Errors == nil {
+		cfg.Errors = ioutil.Discard
+	}
+
+	d := &Dispatcher{
+		cfg:   cfg,
+		queue: make(chan delivery, 64),
+		done:  make(chan struct{}),
+		sent:  map[sentKey][sha256.Size]byte{},
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	go d.run()
+	return d, nil
+}
+
+// Subscribe sends the ranking's events to the webhooks until the returned
+// function is called. Each ranking subscribed is taken to be the match
+// data built again from the start, like after a reload, so payloads that
+// are the same as the last ranking's aren't sent again.
+func (d *Dispatcher) Subscribe(r *games.Ranking) func() {
+	d.previous, d.sent = d.sent, map[sentKey][sha256.Size]byte{}
+	return r.Subscribe(d.Send)
+}
+
+// Send queues the payload for the event, events that aren't sent to
+// webhooks are ignored. Send only waits if a lot of deliveries are
+// already waiting to be made.
+func (d *Dispatcher) Send(e games.Event) {
+	p, ok := newPayload(e)
+	if !ok {
+		return
+	}
+	body, err := p.encode()
+	if err != nil {
+		fmt.Fprintf(d.cfg.Errors, "unable to encode %v webhook: %v\n", p.Event, err)
+		return
+	}
+
+	k := sentKey{event: p.Event, day: p.Day}
+	for _, ok := d.sent[k]; ok; _, ok = d.sent[k] {
+		k.n++
+	}
+	sum := sha256.Sum256(body)
+	d.sent[k] = sum
+	if prev, ok := d.previous[k]; ok && prev == sum {
+		return
+	}
+	d.queue <- delivery{event: p.Event, body: body}
+}
+
+// Close waits for the queued deliveries to be made, Send can't be used
+// once it's been called
+func (d *Dispatcher) Close() {
+	close(d.queue)
+	<-d.done
+	d.cancel()
+}
+
+// Stop is like Close but only waits up to wait for the deliveries to be
+// made. Any that haven't been made by then are given up on, reported &
+// written to the dead letter file with ErrStopped. It returns false if
+// it had to give up on any.
+func (d *Dispatcher) Stop(wait time.Duration) bool {
+	close(d.queue)
+	defer d.cancel()
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-d.done:
+		return true
+	case <-t.C:
+	}
+
+	d.cancel()
+	<-d.done
+	return false
+}
+
+// run makes each delivery to every URL
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	for dl := range d.queue {
+		for _, u := range d.cfg.URLs {
+			d.deliver(u, dl)
+		}
+	}
+}
+
+// deliver sends the payload to the URL, trying again with a longer wait
+// each time until it works or runs out of attempts
+func (d *Dispatcher) deliver(url string, dl delivery) {
+	wait := d.cfg.Backoff
+	var err error
+	retry := true
+	attempts := 0
+	for retry && attempts < d.cfg.Attempts && d.ctx.Err() == nil {
+		if attempts > 0 {
+			select {
+			case <-time.After(wait):
+			case <-d.ctx.Done():
+				continue
+			}
+			wait *= 2
+		}
+		attempts++
+
+		if retry, err = d.post(url, dl); err == nil {
+			return
+		}
+	}
+
+	// Stop gave up waiting before this delivery worked or ran out of tries
+	if retry && d.ctx.Err() != nil {
+		if err != nil {
+			err = fmt.Errorf("%w, last error: %v", ErrStopped, err)
+		} else {
+			err = ErrStopped
+		}
+	}
+
+	fmt.Fprintf(d.cfg.Errors, "unable to send %v webhook to %v after %v attempts: %v\n", dl.event, url, attempts, err)
+	if d.cfg.DeadLetter == "" {
+		return
+	}
+	dead := DeadLetter{Time: time.Now(), URL: url, Event: dl.event, Attempts: attempts, Error: err.Error(), Payload: dl.body}
+	if werr := d.writeDeadLetter(dead); werr != nil {
+		fmt.Fprintf(d.cfg.Errors, "unable to write dead letter: %v\n", werr)
+	}
+}
+
+// post makes a single request, returning true with the error if it's
+// worth trying again
+func (d *Dispatcher) post(url string, dl delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, url, bytes.NewReader(dl.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, dl.event)
+	if d.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, dl.body))
+	}
+
+	resp, err := d.cfg.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("got response %v", resp.Status)
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// writeDeadLetter appends the failed delivery to the dead letter file
+func (d *Dispatcher) writeDeadLetter(dead DeadLetter) error {
+	line, err := json.Marshal(dead)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(d.cfg.DeadLetter, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Sign returns the signature header value for the body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature header value is right for the
+// body, for checking deliveries on the receiving end
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
@@ -0,0 +1,334 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreyvit/diff"
+	"github.com/seanhagen/jane-coding-challenge/games"
+)
+
+// receiver is a test server that records the deliveries it gets, and
+// answers with the status codes it's given before answering 200
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+	events   []string
+	signed   []bool
+	secret   string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.bodies = append(rc.bodies, string(body))
+	rc.events = append(rc.events, r.Header.Get(EventHeader))
+	rc.signed = append(rc.signed, Verify(rc.secret, body, r.Header.Get(SignatureHeader)))
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func TestWebhook_Dispatcher(t *testing.T) {
+	tests := []struct {
+		inputs   []string
+		statuses []int
+
+		// expect is the body of each request the server gets
+		expect []string
+
+		// dead is the number of dead letters written
+		dead int
+	}{
+		{
+			[]string{"A 1, B 0", "C 1, D 1", "A 2, C 0"},
+			[]int{},
+			[]string{
+				`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+				`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":3},{"position":2,"team":"C","points":1},{"position":3,"team":"D","points":1},{"position":4,"team":"B","points":0}]}`,
+				`{"event":"day_closed","day":2,"standings":[{"position":1,"team":"A","points":6},{"position":2,"team":"C","points":1}]}`,
+			},
+			0,
+		},
+		{
+			// server errors are tried again until they work
+			[]string{"A 1, B 0"},
+			[]int{500, 503, 429},
+			[]string{
+				`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+				`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":3},{"position":2,"team":"B","points":0}]}`,
+			},
+			0,
+		},
+		{
+			// out of attempts, then the next payload works
+			[]string{"A 1, B 1"},
+			[]int{500, 500, 500, 500},
+			[]string{
+				`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+				`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+				`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":1},{"position":2,"team":"B","points":1}]}`,
+			},
+			1,
+		},
+		{
+			// client errors aren't tried again
+			[]string{"A 1, B 1"},
+			[]int{400},
+			[]string{
+				`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+				`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":1},{"position":2,"team":"B","points":1}]}`,
+			},
+			1,
+		},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			rc := &receiver{statuses: tt.statuses, secret: "shh"}
+			srv := httptest.NewServer(rc)
+			defer srv.Close()
+
+			dead := filepath.Join(t.TempDir(), "dead.jsonl")
+			d, err := New(Config{URLs: []string{srv.URL}, Secret: "shh", Attempts: 4, Backoff: time.Millisecond, DeadLetter: dead})
+			if err != nil {
+				t.Fatalf("unable to create dispatcher: %v", err)
+			}
+
+			r := games.NewRanking()
+			d.Subscribe(r)
+			for _, in := range tt.inputs {
+				if err := r.AddMatch(in); err != nil {
+					t.Fatalf("unable to add '%v': %v", in, err)
+				}
+			}
+			r.Finish()
+			d.Close()
+
+			expect, got := strings.Join(tt.expect, "\n"), strings.Join(rc.bodies, "\n")
+			if got != expect {
+				t.Errorf("wrong deliveries\ndiff:\n%v", diff.LineDiff(expect, got))
+			}
+			for j, ok := range rc.signed {
+				if !ok {
+					t.Errorf("delivery %v doesn't have a valid signature", j)
+				}
+				var p Payload
+				if err := json.Unmarshal([]byte(rc.bodies[j]), &p); err != nil || p.Event != rc.events[j] {
+					t.Errorf("delivery %v has event header '%v', expected '%v'", j, rc.events[j], p.Event)
+				}
+			}
+
+			letters := readDeadLetters(t, dead)
+			if len(letters) != tt.dead {
+				t.Fatalf("expected %v dead letters, got %v", tt.dead, len(letters))
+			}
+			for _, dl := range letters {
+				if dl.URL != srv.URL || string(dl.Payload) != tt.expect[0] {
+					t.Errorf("wrong dead letter: %+v", dl)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_Dispatcher_Rebuilt(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d, err := New(Config{URLs: []string{srv.URL, srv.URL}})
+	if err != nil {
+		t.Fatalf("unable to create dispatcher: %v", err)
+	}
+
+	// building the ranking again only sends what's changed
+	for _, inputs := range [][]string{
+		{"A 1, B 0", "A 1, B 1"},
+		{"A 1, B 0", "A 1, B 1", "A 0, B 0"},
+		{"A 1, B 1", "A 0, B 1", "A 0, B 0"},
+	} {
+		r := games.NewRanking()
+		d.Subscribe(r)
+		for _, in := range inputs {
+			if err := r.AddMatch(in); err != nil {
+				t.Fatalf("unable to add '%v': %v", in, err)
+			}
+		}
+	}
+	d.Close()
+
+	expect := []string{
+		`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+		`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":3},{"position":2,"team":"B","points":0}]}`,
+		`{"event":"day_closed","day":2,"standings":[{"position":1,"team":"A","points":4},{"position":2,"team":"B","points":1}]}`,
+		`{"event":"leader_changed","day":1,"leader":"A","points":1}`,
+		`{"event":"day_closed","day":1,"standings":[{"position":1,"team":"A","points":1},{"position":2,"team":"B","points":1}]}`,
+		`{"event":"leader_changed","day":2,"leader":"B","previous":"A","points":4}`,
+		`{"event":"day_closed","day":2,"standings":[{"position":1,"team":"B","points":4},{"position":2,"team":"A","points":1}]}`,
+	}
+	got := []string{}
+	for i := 0; i < len(rc.bodies); i += 2 {
+		if rc.bodies[i] != rc.bodies[i+1] {
+			t.Errorf("expected the same delivery to both URLs, got:\n%v\n%v", rc.bodies[i], rc.bodies[i+1])
+		}
+		got = append(got, rc.bodies[i])
+	}
+	if strings.Join(got, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("wrong deliveries\ndiff:\n%v", diff.LineDiff(strings.Join(expect, "\n"), strings.Join(got, "\n")))
+	}
+	for _, e := range rc.events {
+		if e == "" {
+			t.Errorf("expected every delivery to have an event header")
+		}
+	}
+}
+
+// TestWebhook_Dispatcher_Repeated checks a payload that's the same as an
+// earlier one from the same ranking is still sent, and that only the last
+// ranking's payloads are kept
+func TestWebhook_Dispatcher_Repeated(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	d, err := New(Config{URLs: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("unable to create dispatcher: %v", err)
+	}
+
+	// the lead changes hands back & forth on the same day
+	events := []games.Event{
+		games.LeaderChangedEvent{Day: 1, Leader: "A", Points: 3},
+		games.LeaderChangedEvent{Day: 1, Leader: "B", Previous: "A", Points: 3},
+		games.LeaderChangedEvent{Day: 1, Leader: "A", Previous: "B", Points: 3},
+		games.LeaderChangedEvent{Day: 1, Leader: "B", Previous: "A", Points: 3},
+	}
+	for i := 0; i < 3; i++ {
+		d.Subscribe(games.NewRanking())
+		for _, e := range events[:2+i] {
+			d.Send(e)
+		}
+		if len(d.sent) != 2+i || len(d.previous) > 2+i {
+			t.Errorf("expected only the last two rankings' payloads to be kept, got %v & %v", len(d.sent), len(d.previous))
+		}
+	}
+	d.Close()
+
+	expect := []string{
+		`{"event":"leader_changed","day":1,"leader":"A","points":3}`,
+		`{"event":"leader_changed","day":1,"leader":"B","previous":"A","points":3}`,
+		`{"event":"leader_changed","day":1,"leader":"A","previous":"B","points":3}`,
+		`{"event":"leader_changed","day":1,"leader":"B","previous":"A","points":3}`,
+	}
+	if got := strings.Join(rc.bodies, "\n"); got != strings.Join(expect, "\n") {
+		t.Errorf("wrong deliveries\ndiff:\n%v", diff.LineDiff(strings.Join(expect, "\n"), got))
+	}
+}
+
+// TestWebhook_Dispatcher_Stop checks the deliveries that haven't been made
+// when Stop gives up waiting are written to the dead letter file
+func TestWebhook_Dispatcher_Stop(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	dead := filepath.Join(t.TempDir(), "dead.jsonl")
+	d, err := New(Config{URLs: []string{srv.URL}, DeadLetter: dead})
+	if err != nil {
+		t.Fatalf("unable to create dispatcher: %v", err)
+	}
+
+	d.Subscribe(games.NewRanking())
+	events := []games.Event{
+		games.LeaderChangedEvent{Day: 1, Leader: "A", Points: 3},
+		games.LeaderChangedEvent{Day: 2, Leader: "B", Previous: "A", Points: 6},
+		games.LeaderChangedEvent{Day: 3, Leader: "A", Previous: "B", Points: 9},
+	}
+	for _, e := range events {
+		d.Send(e)
+	}
+	if d.Stop(50 * time.Millisecond) {
+		t.Errorf("expected Stop to give up waiting")
+	}
+
+	letters := readDeadLetters(t, dead)
+	if len(letters) != len(events) {
+		t.Fatalf("expected %v dead letters, got %v", len(events), len(letters))
+	}
+	for i, dl := range letters {
+		var p Payload
+		if err := json.Unmarshal(dl.Payload, &p); err != nil || p.Day != i+1 {
+			t.Errorf("expected dead letter %v to be for day %v, got %+v", i, i+1, dl)
+		}
+		if !strings.HasPrefix(dl.Error, ErrStopped.Error()) {
+			t.Errorf("expected dead letter %v to be stopped, got error '%v'", i, dl.Error)
+		}
+	}
+}
+
+func TestWebhook_New_Invalid(t *testing.T) {
+	tests := []Config{
+		{},
+		{URLs: []string{"ftp://example.com/hook"}},
+		{URLs: []string{"http://example.com/hook", "::not a url"}},
+	}
+
+	for i, x := range tests {
+		tt := x
+		t.Run(fmt.Sprintf("test_%v_", i), func(t *testing.T) {
+			if _, err := New(tt); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+// readDeadLetters returns the dead letters in the file, none if it
+// doesn't exist
+func readDeadLetters(t *testing.T, path string) []DeadLetter {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("unable to open dead letter file: %v", err)
+	}
+	defer f.Close()
+
+	out := []DeadLetter{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(sc.Bytes(), &dl); err != nil {
+			t.Fatalf("invalid dead letter '%v': %v", sc.Text(), err)
+		}
+		out = append(out, dl)
+	}
+	return out
+}